@@ -51,6 +51,23 @@ get_value_from() {
   [[ "${count}" == "1" ]]
 }
 
+@test "api-validation" {
+  tmp="tests/checks/api-validation.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include api-validation --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: spec.template.metadata.labels: Invalid value: "*": \`selector\` does not match template \`labels\` (Kubernetes 1."*" validation)" ]]
+  [[ "${message2}" == "Service: spec.ports[0].nodePort: Forbidden: may not be used when \`type\` is 'ClusterIP' (Kubernetes 1."*" validation)" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "cluster-admin-role-binding" {
   tmp="tests/checks/cluster-admin-role-binding.yml"
   cmd="${KUBE_LINTER_BIN} lint --include cluster-admin-role-binding --do-not-auto-add-defaults --format json ${tmp}"
@@ -162,6 +179,25 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "deprecated-annotations-and-fields" {
+  tmp="tests/checks/deprecated-annotations-and-fields.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include deprecated-annotations-and-fields --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  message3=$(get_value_from "${lines[0]}" '.Reports[2].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[2].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: annotation \"seccomp.security.alpha.kubernetes.io/pod\" is deprecated since Kubernetes 1.19: use the seccompProfile field of the pod security context instead" ]]
+  [[ "${message2}" == "Deployment: node label \"failure-domain.beta.kubernetes.io/zone\" is deprecated since Kubernetes 1.17: use topology.kubernetes.io/zone instead" ]]
+  [[ "${message3}" == "Ingress: annotation \"kubernetes.io/ingress.class\" is deprecated since Kubernetes 1.18: use spec.ingressClassName instead" ]]
+  [[ "${count}" == "3" ]]
+}
+
 @test "deprecated-service-account-field" {
   tmp="tests/checks/deprecated-service-account-field.yml"
   cmd="${KUBE_LINTER_BIN} lint --include deprecated-service-account-field --do-not-auto-add-defaults --format json ${tmp}"
@@ -264,6 +300,20 @@ get_value_from() {
   [[ "${count}" == "1" ]]
 }
 
+@test "helm-test-pods" {
+  tmp="tests/testdata/mychart"
+  cmd="${KUBE_LINTER_BIN} lint --include helm-test-pods --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 0 ]
+
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  # The test pod of the chart is declared as a test hook.
+  [[ "${count}" == "0" ]]
+}
+
 @test "host-ipc" {
   tmp="tests/checks/host-ipc.yml"
   cmd="${KUBE_LINTER_BIN} lint --include host-ipc --do-not-auto-add-defaults --format json ${tmp}"
@@ -330,6 +380,55 @@ get_value_from() {
   [[ "${count}" == "1" ]]
 }
 
+@test "immutable-field-changed" {
+  tmp="tests/checks/immutable-field-changed.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include immutable-field-changed --do-not-auto-add-defaults --format json --compare-to tests/checks/previous/immutable-field-changed.yml ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: spec.selector changed, but the field is immutable: the Deployment has to be deleted and recreated to apply the change" ]]
+  [[ "${message2}" == "StatefulSet: spec.serviceName changed from \"database\" to \"database-headless\", but the field is immutable: the StatefulSet has to be deleted and recreated to apply the change" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "inconsistent-pod-spec" {
+  tmp="tests/checks/inconsistent-pod-spec.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include inconsistent-pod-spec --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: container \"app\": volumeMounts[0].name refers to volume \"data\", which is not defined" ]]
+  [[ "${message2}" == "Deployment: container \"app\": env[0].value refers to \$(HOST), which is only defined later" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "init-container-probes" {
+  tmp="tests/checks/init-container-probes.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include init-container-probes --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: init container \"setup\" specifies a liveness probe, but only native sidecars can have probes" ]]
+  [[ "${count}" == "1" ]]
+}
+
 @test "invalid-target-ports" {
   tmp="tests/checks/invalid-target-ports.yaml"
   cmd="${KUBE_LINTER_BIN} lint --include invalid-target-ports --do-not-auto-add-defaults --format json ${tmp}"
@@ -406,6 +505,21 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "native-sidecar-ordering" {
+  tmp="tests/checks/native-sidecar-ordering.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include native-sidecar-ordering --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: native sidecar \"proxy\" is declared after init container \"migrate\", which runs before the sidecar starts" ]]
+  [[ "${count}" == "1" ]]
+}
+
 @test "no-anti-affinity" {
   tmp="tests/checks/no-anti-affinity.yml"
   cmd="${KUBE_LINTER_BIN} lint --include no-anti-affinity --do-not-auto-add-defaults --format json ${tmp}"
@@ -450,11 +564,13 @@ get_value_from() {
 
   message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
   message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  message3=$(get_value_from "${lines[0]}" '.Reports[2].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[2].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
   [[ "${message1}" == "Deployment: container \"app1\" does not specify a liveness probe" ]]
   [[ "${message2}" == "DeploymentConfig: container \"app2\" does not specify a liveness probe" ]]
-  [[ "${count}" == "2" ]]
+  [[ "${message3}" == "Deployment: container \"proxy\" does not specify a liveness probe" ]]
+  [[ "${count}" == "3" ]]
 }
 
 @test "no-node-affinity" {
@@ -557,6 +673,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "overlapping-selectors" {
+  tmp="tests/checks/overlapping-selectors.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include overlapping-selectors --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: selector (app=web) also matches the pods of Deployment \"web-canary\"" ]]
+  [[ "${message2}" == "Service: service selector (app=web) matches the pods of 2 workloads: Deployment \"web\", Deployment \"web-canary\"" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "privilege-escalation-container" {
   tmp="tests/checks/privilege-escalation-container.yml"
   cmd="${KUBE_LINTER_BIN} lint --include privilege-escalation-container --do-not-auto-add-defaults --format json ${tmp}"
@@ -625,6 +758,21 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "renamed-object" {
+  tmp="tests/checks/renamed-object.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include renamed-object --do-not-auto-add-defaults --format json --compare-to tests/checks/previous/renamed-object.yml ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: object appears to be Deployment \"backend\" of the previous revision under a new name: applying the change deletes \"backend\" and creates a new object, rather than updating it" ]]
+  [[ "${count}" == "1" ]]
+}
+
 @test "required-annotation-email" {
   tmp="tests/checks/required-annotation-email.yml"
   cmd="${KUBE_LINTER_BIN} lint --include required-annotation-email --do-not-auto-add-defaults --format json ${tmp}"
@@ -693,6 +841,40 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "server-populated-fields" {
+  tmp="tests/checks/server-populated-fields.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include server-populated-fields --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  line1=$(get_value_from "${lines[0]}" '.Reports[0].Diagnostic.Line')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "ConfigMap: object has fields populated by the API server or kubectl, which cause conflicts when it is applied: metadata.uid, metadata.resourceVersion" ]]
+  [[ "${line1}" == "5" ]]
+  [[ "${message2}" == "Service: object has fields populated by the API server or kubectl, which cause conflicts when it is applied: status" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "size-limits" {
+  tmp="tests/checks/size-limits.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include size-limits --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "ConfigMap: label \"description\" has a value of 74 characters, above the limit of 63" ]]
+  [[ "${count}" == "1" ]]
+}
+
 @test "ssh-port" {
   tmp="tests/checks/ssh-port.yml"
   cmd="${KUBE_LINTER_BIN} lint --include ssh-port --do-not-auto-add-defaults --format json ${tmp}"
@@ -712,6 +894,25 @@ get_value_from() {
   [[ "${count}" == "3" ]]
 }
 
+@test "suspicious-resource-quantities" {
+  tmp="tests/checks/suspicious-resource-quantities.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include suspicious-resource-quantities --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  message3=$(get_value_from "${lines[0]}" '.Reports[2].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[2].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: container \"app\" has cpu request 1000, which is above 64 cores: quantities without a suffix are cores, use 1000m for millicores" ]]
+  [[ "${message2}" == "Deployment: container \"app\" has memory request 512m, which is a fraction of a byte: the m suffix means milli, use Mi for mebibytes" ]]
+  [[ "${message3}" == "Deployment: container \"app\" has cpu request 1000 above its limit 2000m" ]]
+  [[ "${count}" == "3" ]]
+}
+
 @test "unsafe-proc-mount" {
   tmp="tests/checks/unsafe-proc-mount.yml"
   cmd="${KUBE_LINTER_BIN} lint --include unsafe-proc-mount --do-not-auto-add-defaults --format json ${tmp}"
@@ -746,6 +947,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "unschedulable-workload" {
+  tmp="tests/checks/unschedulable-workload.yml"
+  cmd="${KUBE_LINTER_BIN} lint --config e2etests/testdata/node-pools-config.yaml --include unschedulable-workload --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: pods can't be scheduled on the declared node pools: 0/4 nodes are available: 1 node(s) had untolerated taint {nvidia.com/gpu: present}, 3 Insufficient memory" ]]
+  [[ "${message2}" == "Deployment: only 3 of 4 replicas can be scheduled on the declared node pools: 0/4 nodes are available: 1 node(s) had untolerated taint {nvidia.com/gpu: present}, 3 node(s) didn't match pod anti-affinity rules" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "unset-cpu-requirements" {
   tmp="tests/checks/unset-cpu-requirements.yml"
   cmd="${KUBE_LINTER_BIN} lint --include unset-cpu-requirements --do-not-auto-add-defaults --format json ${tmp}"
@@ -835,6 +1053,27 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "yaml-pitfalls" {
+  tmp="tests/checks/yaml-pitfalls.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include yaml-pitfalls --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  line1=$(get_value_from "${lines[0]}" '.Reports[0].Diagnostic.Line')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  line2=$(get_value_from "${lines[0]}" '.Reports[1].Diagnostic.Line')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: mode 0644 is octal in YAML 1.1, which Kubernetes uses, but the decimal 644 in YAML 1.2" ]]
+  [[ "${line1}" == "21" ]]
+  [[ "${message2}" == "ConfigMap: unquoted on is the boolean true in YAML 1.1, which Kubernetes uses, but the string \"on\" in YAML 1.2, and Kubernetes turns it into the key \"true\"" ]]
+  [[ "${line2}" == "6" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "template-forbidden-annotation" {
   tmp="tests/checks/forbidden-annotation.yml"
   cmd="${KUBE_LINTER_BIN} lint --config e2etests/testdata/forbidden-annotation-config.yaml --do-not-auto-add-defaults --format json ${tmp}"
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
 )
 
 func TestBuiltInChecksWellFormed(t *testing.T) {
@@ -15,6 +16,11 @@ func TestBuiltInChecksWellFormed(t *testing.T) {
 		t.Run(check.Name, func(t *testing.T) {
 			assert.NotEmpty(t, check.Remediation, "Please add remediation")
 			assert.True(t, strings.HasSuffix(check.Remediation, "."), "Please end your remediation texts with a period (got %q)", check.Remediation)
+			assert.NotEmpty(t, check.Category, "Please add a category")
+			assert.NotEmpty(t, check.Tags, "Please add at least one tag")
+			if check.Category == "security" {
+				assert.Contains(t, config.Severities, check.Severity, "Please add a severity to security checks")
+			}
 		})
 	}
 }
@@ -1,9 +1,5 @@
 package check
 
-import (
-	"golang.stackrox.io/kube-linter/internal/pointers"
-)
-
 // ParameterType represents the expected type of a particular parameter.
 type ParameterType string
 
@@ -18,6 +14,20 @@ const (
 	ArrayType   ParameterType = "array"
 )
 
+// MatcherCapability declares which parts of the value matching language (see matcher.ForString)
+// a parameter supports.
+type MatcherCapability string
+
+// This block enumerates all known matcher capabilities.
+const (
+	// NoMatcher means the parameter value is used literally.
+	NoMatcher MatcherCapability = ""
+	// FullMatcher means the parameter supports the full matcher language.
+	FullMatcher MatcherCapability = "full"
+	// NonNegatableMatcher means the parameter supports the matcher language, except for negation via a leading !.
+	NonNegatableMatcher MatcherCapability = "non-negatable"
+)
+
 // ParameterDesc describes a parameter.
 type ParameterDesc struct {
 	Name        string
@@ -41,13 +51,10 @@ type ParameterDesc struct {
 	// Required denotes whether the parameter is required.
 	Required bool
 
-	// NoRegex is set if the parameter does not support regexes.
-	// Only relevant if Type is "string".
-	NoRegex bool
-
-	// NotNegatable is set if the parameter does not support negation via a leading !.
-	// OnlyRelevant if Type is "string".
-	NotNegatable bool
+	// Matcher declares whether the parameter is a matcher expression, and which parts of the
+	// matcher language it supports.
+	// Only relevant if Type is "string", or "array" with an ArrayElemType of "string".
+	Matcher MatcherCapability
 
 	// Fields below are for internal use only.
 
@@ -59,16 +66,15 @@ type ParameterDesc struct {
 // It is intended only for API documentation/JSON marshaling, and must NOT be used for
 // any business logic.
 type HumanReadableParamDesc struct {
-	Name            string                   `json:"name"`
-	Type            ParameterType            `json:"type"`
-	Description     string                   `json:"description"`
-	Required        bool                     `json:"required"`
-	Examples        []string                 `json:"examples,omitempty"`
-	RegexAllowed    *bool                    `json:"regexAllowed,omitempty"`
-	NegationAllowed *bool                    `json:"negationAllowed,omitempty"`
-	SubParameters   []HumanReadableParamDesc `json:"subParameters,omitempty"`
-	ArrayElemType   ParameterType            `json:"arrayElemType,omitempty"`
-	NestingLevel    int                      `json:"-"` // NestingLevel controls output indentation for sub-params.
+	Name          string                   `json:"name"`
+	Type          ParameterType            `json:"type"`
+	Description   string                   `json:"description"`
+	Required      bool                     `json:"required"`
+	Examples      []string                 `json:"examples,omitempty"`
+	Matcher       MatcherCapability        `json:"matcher,omitempty"`
+	SubParameters []HumanReadableParamDesc `json:"subParameters,omitempty"`
+	ArrayElemType ParameterType            `json:"arrayElemType,omitempty"`
+	NestingLevel  int                      `json:"-"` // NestingLevel controls output indentation for sub-params.
 }
 
 // HumanReadableFields returns a human-friendly representation of this ParameterDesc.
@@ -88,8 +94,7 @@ func transform(p *ParameterDesc, nestingLevel int) HumanReadableParamDesc {
 
 	if p.Type == StringType ||
 		(p.Type == ArrayType && p.ArrayElemType == StringType) {
-		out.RegexAllowed = pointers.Bool(!p.NoRegex)
-		out.NegationAllowed = pointers.Bool(!p.NotNegatable)
+		out.Matcher = p.Matcher
 	}
 
 	if p.Type == ArrayType {
@@ -0,0 +1,99 @@
+// Package clusterprofiles holds the built-in cluster profiles, which describe the Kubernetes version and the API
+// versions served by common kinds of clusters, so that Helm charts are rendered with the capabilities of the cluster
+// they are deployed to.
+package clusterprofiles
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var (
+	//go:embed profiles
+	yamlFiles embed.FS
+
+	loadOnce sync.Once
+	profiles []Profile
+	loadErr  error
+)
+
+// A Profile describes a kind of cluster.
+type Profile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// KubeVersion is the version of Kubernetes that the cluster runs.
+	KubeVersion string `json:"kubeVersion"`
+	// APIVersions are the group versions that the cluster serves, e.g. apps/v1, and the kinds of group versions that
+	// are not known to client-go, e.g. route.openshift.io/v1/Route.
+	APIVersions []string `json:"apiVersions"`
+}
+
+// VersionSet returns the API versions of the profile as Helm capabilities: the API versions of the profile, along
+// with the kinds of these group versions that client-go knows, like in the default capabilities of Helm.
+func (p *Profile) VersionSet() chartutil.VersionSet {
+	versions := make(chartutil.VersionSet, 0, len(p.APIVersions))
+	versions = append(versions, p.APIVersions...)
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if versions.Has(gvk.GroupVersion().String()) {
+			versions = append(versions, gvk.GroupVersion().String()+"/"+gvk.Kind)
+		}
+	}
+	return versions
+}
+
+// List lists the built-in cluster profiles.
+func List() ([]Profile, error) {
+	loadOnce.Do(func() {
+		fileEntries, err := yamlFiles.ReadDir("profiles")
+		if err != nil {
+			loadErr = errors.Wrap(err, "reading embedded yaml files")
+			return
+		}
+		for _, entry := range fileEntries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+				loadErr = errors.Errorf("found unexpected entry %s in profiles directory", entry.Name())
+				return
+			}
+			// Do NOT use filepath.Join here, because embed always uses `/` as the separator,
+			// irrespective of the OS we're running.
+			contents, err := yamlFiles.ReadFile(fmt.Sprintf("profiles/%s", entry.Name()))
+			if err != nil {
+				loadErr = errors.Wrapf(err, "loading file %s", entry.Name())
+				return
+			}
+			var profile Profile
+			if err := yaml.Unmarshal(contents, &profile); err != nil {
+				loadErr = errors.Wrapf(err, "unmarshalling cluster profile from %s", entry.Name())
+				return
+			}
+			profiles = append(profiles, profile)
+		}
+	})
+	if loadErr != nil {
+		return nil, errors.Wrap(loadErr, "UNEXPECTED: failed to load cluster profiles")
+	}
+	return profiles, nil
+}
+
+// Get returns the cluster profile with the given name.
+func Get(name string) (*Profile, error) {
+	all, err := List()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(all))
+	for i := range all {
+		if all[i].Name == name {
+			return &all[i], nil
+		}
+		names = append(names, all[i].Name)
+	}
+	return nil, errors.Errorf("unknown cluster profile %q, must be one of %v", name, names)
+}
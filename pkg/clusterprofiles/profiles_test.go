@@ -0,0 +1,46 @@
+package clusterprofiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestList(t *testing.T) {
+	profiles, err := List()
+	require.NoError(t, err)
+	require.NotEmpty(t, profiles)
+	for _, profile := range profiles {
+		_, err := chartutil.ParseKubeVersion(profile.KubeVersion)
+		assert.NoError(t, err, profile.Name)
+		assert.NotEmpty(t, profile.APIVersions, profile.Name)
+	}
+}
+
+func TestVersionSet(t *testing.T) {
+	profile, err := Get("kubernetes-1.24")
+	require.NoError(t, err)
+	versions := profile.VersionSet()
+	assert.True(t, versions.Has("batch/v1beta1"))
+	assert.True(t, versions.Has("batch/v1beta1/CronJob"))
+	assert.True(t, versions.Has("apps/v1/Deployment"))
+
+	profile, err = Get("kubernetes-1.26")
+	require.NoError(t, err)
+	versions = profile.VersionSet()
+	assert.False(t, versions.Has("batch/v1beta1"))
+	assert.False(t, versions.Has("batch/v1beta1/CronJob"))
+	assert.True(t, versions.Has("batch/v1/CronJob"))
+	assert.False(t, versions.Has("route.openshift.io/v1/Route"))
+
+	profile, err = Get("openshift-4.12")
+	require.NoError(t, err)
+	assert.True(t, profile.VersionSet().Has("route.openshift.io/v1/Route"))
+}
+
+func TestGetUnknown(t *testing.T) {
+	_, err := Get("does-not-exist")
+	assert.Error(t, err)
+}
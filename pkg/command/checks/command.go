@@ -25,6 +25,9 @@ const (
 Name: {{.Name}}
 Description: {{.Description}}
 Remediation: {{.Remediation}}
+Category: {{.Category}}
+Severity: {{.Severity}}
+Tags: {{ join ", " .Tags }}
 Template: {{.Template}}
 Parameters: {{.Params}}
 Enabled by default: {{ isDefault . }}
@@ -44,6 +47,16 @@ KubeLinter includes the following built-in checks:
 
 **Remediation**: {{.Remediation}}
 
+**Category**: {{.Category}}
+
+{{ if .Severity -}}
+**Severity**: {{.Severity}}
+
+{{ end -}}
+{{ if .Tags -}}
+**Tags**: {{ range $i, $tag := .Tags }}{{ if $i }}, {{ end }}{{ codeSnippet $tag }}{{ end }}
+
+{{ end -}}
 **Template**: [{{.Template}}](templates.md#{{ templateLink . }})
 {{ if .Params }}
 **Parameters**:
@@ -20,6 +20,8 @@ const (
 	// SARIFFormat is JSON-based standard for reporting lint errors.
 	// See https://www.oasis-open.org/committees/tc_home.php?wg_abbrev=sarif
 	SARIFFormat = "sarif"
+	// HTMLFormat is for standalone HTML reports.
+	HTMLFormat = "html"
 )
 
 // FormatFunc sets contract formatter of each FormatType should follow.
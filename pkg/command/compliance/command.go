@@ -0,0 +1,192 @@
+package compliance
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.stackrox.io/kube-linter/internal/flagutil"
+	"golang.stackrox.io/kube-linter/internal/utils"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/compliance"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/configresolver"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	plainTemplateStr = `KubeLinter {{.Summary.KubeLinterVersion}} compliance report: {{.Framework.Title | bold}}
+
+{{range .Controls -}}
+{{ if eq .Status "fail" }}{{ printf "[%s]" .Status | red }}{{ else }}[{{.Status}}]{{ end }} {{.Control.ID | bold}} {{.Control.Title}}
+{{- if eq .Status "not-covered" }}
+    {{.Control.NotCoveredReason}}
+{{- end }}
+{{- range .Reports }}
+    {{.Object.Metadata.FilePath}}: (object: {{.Object.GetK8sObjectName}}) {{.Diagnostic.Message | red}} (check: {{.Check | yellow}})
+{{- end }}
+{{end}}
+{{.Summary.Passed}} passed, {{.Summary.Failed}} failed, {{.Summary.NotCovered}} not covered.
+`
+
+	htmlTemplateStr = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Framework.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.pass { color: #1a7f37; }
+.fail { color: #cf222e; }
+.not-covered { color: #6e7781; }
+</style>
+</head>
+<body>
+<h1>{{.Framework.Title}}</h1>
+{{- if .Framework.URL }}
+<p><a href="{{.Framework.URL}}">{{.Framework.URL}}</a></p>
+{{- end }}
+<p>KubeLinter {{.Summary.KubeLinterVersion}}: {{.Summary.Passed}} passed, {{.Summary.Failed}} failed, {{.Summary.NotCovered}} not covered.</p>
+<table>
+<tr><th>Control</th><th>Title</th><th>Status</th><th>Details</th></tr>
+{{- range .Controls }}
+<tr>
+<td>{{.Control.ID}}</td>
+<td>{{.Control.Title}}</td>
+<td class="{{.Status}}">{{.Status}}</td>
+<td>
+{{- if eq .Status "not-covered" }}{{.Control.NotCoveredReason}}{{ else }}checks: {{ join .Control.Checks ", " }}{{ end }}
+{{- if .Reports }}
+<ul>
+{{- range .Reports }}
+<li>{{.Object.Metadata.FilePath}} ({{.Object.GetK8sObjectName}}): {{.Diagnostic.Message}} (check: {{.Check}})</li>
+{{- end }}
+</ul>
+{{- end -}}
+</td>
+</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`
+)
+
+var (
+	plainTemplate = common.MustInstantiatePlainTemplate(plainTemplateStr, nil)
+	htmlTemplate  = mustInstantiateHTMLTemplate(htmlTemplateStr)
+
+	formatters = common.Formatters{
+		Formatters: map[common.FormatType]common.FormatFunc{
+			common.PlainFormat: plainTemplate.Execute,
+			common.JSONFormat:  common.FormatJSON,
+			common.HTMLFormat:  htmlTemplate.Execute,
+		},
+	}
+)
+
+func mustInstantiateHTMLTemplate(templateStr string) *htmltemplate.Template {
+	tpl, err := htmltemplate.New("").Funcs(htmltemplate.FuncMap{"join": strings.Join}).Parse(templateStr)
+	utils.Must(err)
+	return tpl
+}
+
+// Command is the command for the compliance command.
+func Command() *cobra.Command {
+	var frameworkName string
+	var configPath string
+	format := flagutil.NewEnumFlag("Output format", formatters.GetEnabledFormatters(), common.PlainFormat)
+
+	v := viper.New()
+
+	c := &cobra.Command{
+		Use:   "compliance",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Report compliance of Kubernetes YAML files and Helm charts with a framework such as the CIS Benchmark",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			framework, err := compliance.Get(frameworkName)
+			if err != nil {
+				return err
+			}
+
+			checkRegistry := checkregistry.New()
+			if err := builtinchecks.LoadInto(checkRegistry); err != nil {
+				return err
+			}
+
+			cfg, err := config.Load(v, configPath)
+			if err != nil {
+				return errors.Wrap(err, "failed to load config")
+			}
+			if err := configresolver.LoadCustomChecksInto(&cfg, checkRegistry); err != nil {
+				return err
+			}
+			// The checks of the framework are run unless the configuration excludes them, as if they were included.
+			cfg.Checks.Include = append(cfg.Checks.Include, framework.Checks()...)
+			enabledChecks, err := configresolver.GetEnabledChecksAndValidate(&cfg, checkRegistry)
+			if err != nil {
+				return err
+			}
+			var checks []string
+			for _, check := range enabledChecks {
+				if framework.Evaluates(&checkRegistry.Load(check).Spec) {
+					checks = append(checks, check)
+				}
+			}
+
+			lintCtxs, err := lintcontext.CreateContextsWithOptions(lintcontext.Options{
+				NodePools: cfg.NodePools,
+				Helm:      cfg.Helm,
+				Ytt:       cfg.Ytt,
+			}, args...)
+			if err != nil {
+				return err
+			}
+			result, err := run.Run(lintCtxs, checkRegistry, checks)
+			if err != nil {
+				return err
+			}
+			report := compliance.Evaluate(framework, result)
+
+			formatter, err := formatters.FormatterByType(format.String())
+			if err != nil {
+				return err
+			}
+			if err := formatter(os.Stdout, report); err != nil {
+				return errors.Wrap(err, "output formatting failed")
+			}
+
+			if report.Summary.Failed > 0 {
+				return errors.Errorf("found %d failing controls", report.Summary.Failed)
+			}
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&frameworkName, "framework", "", fmt.Sprintf("Compliance framework to report on (one of %v)", frameworkNames()))
+	utils.Must(c.MarkFlagRequired("framework"))
+	c.Flags().Var(format, "format", format.Usage())
+	c.Flags().StringVar(&configPath, "config", "", "Path to config file")
+
+	config.AddFlags(c, v)
+	return c
+}
+
+func frameworkNames() []string {
+	frameworks, err := compliance.List()
+	utils.Must(err)
+	names := make([]string, 0, len(frameworks))
+	for _, framework := range frameworks {
+		names = append(names, framework.Name)
+	}
+	return names
+}
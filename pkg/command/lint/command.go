@@ -29,6 +29,9 @@ const (
 
 {{else}}No lint errors found!
 {{end -}}
+{{range .Notes}}
+{{- "Info" | yellow}}: {{.Object.Metadata.FilePath | bold}}{{if .Object.Metadata.LineNumber}}:{{.Object.Metadata.LineNumber}}{{end}}: {{.Diagnostic.Message}} (check: {{.Check}})
+{{end -}}
 `
 )
 
@@ -50,6 +53,11 @@ func Command() *cobra.Command {
 	var failIfNoObjects bool
 	var verbose bool
 	var errorOnInvalidResource bool
+	var applyDefaults bool
+	var compareTo string
+	var environment string
+	var markdown bool
+	var terraform bool
 	format := flagutil.NewEnumFlag("Output format", formatters.GetEnabledFormatters(), common.PlainFormat)
 
 	v := viper.New()
@@ -81,10 +89,25 @@ func Command() *cobra.Command {
 				fmt.Fprintln(os.Stderr, "Warning: no checks enabled.")
 				return nil
 			}
-			lintCtxs, err := lintcontext.CreateContexts(args...)
+			options := lintcontext.Options{
+				ApplyDefaults:       applyDefaults,
+				NodePools:           cfg.NodePools,
+				Helm:                cfg.Helm,
+				HelmfileEnvironment: environment,
+				Markdown:            markdown,
+				Terraform:           terraform,
+				Ytt:                 cfg.Ytt,
+			}
+			lintCtxs, err := lintcontext.CreateContextsWithOptions(options, args...)
 			if err != nil {
 				return err
 			}
+			if compareTo != "" {
+				lintCtxs, err = withPreviousRevision(lintCtxs, options, compareTo, args)
+				if err != nil {
+					return err
+				}
+			}
 			invalidObjectsResult := generateReportFromInvalidObjects(lintCtxs)
 			if verbose {
 				for _, invalidObj := range invalidObjectsResult {
@@ -138,6 +161,11 @@ func Command() *cobra.Command {
 	c.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
 	c.Flags().Var(format, "format", format.Usage())
 	c.Flags().BoolVarP(&errorOnInvalidResource, "fail-on-invalid-resource", "", false, "Error out when we have an invalid resource")
+	c.Flags().BoolVarP(&applyDefaults, "apply-defaults", "", false, "Apply the defaults that the Kubernetes API server sets on objects, and the default resources of LimitRanges, before running checks")
+	c.Flags().StringVar(&compareTo, "compare-to", "", "Path or git revision of the previous version of the linted files, which transition checks such as immutable-field-changed compare the objects to")
+	c.Flags().StringVar(&environment, "environment", lintcontext.DefaultHelmfileEnvironment, "Environment of helmfiles that their releases are rendered for")
+	c.Flags().BoolVar(&markdown, "markdown", false, fmt.Sprintf("Lint the manifests in the fenced YAML code blocks of Markdown files, except for the blocks marked with %s", lintcontext.MarkdownSkipMarker))
+	c.Flags().BoolVar(&terraform, "terraform", false, "Lint the Kubernetes objects that the resources of Terraform files declare, and the local charts of their helm_release resources")
 
 	config.AddFlags(c, v)
 	return c
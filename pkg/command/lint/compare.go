@@ -0,0 +1,170 @@
+package lint
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// withPreviousRevision loads the previous revision of the manifests given by compareTo, and returns lint contexts
+// that compare the objects of lintCtxs to it. compareTo is either the path of the previous revision, or a git
+// revision of the repository containing the linted paths, in which case the same paths are loaded from that revision.
+func withPreviousRevision(lintCtxs []lintcontext.LintContext, options lintcontext.Options, compareTo string, args []string) ([]lintcontext.LintContext, error) {
+	if _, err := os.Stat(compareTo); err == nil {
+		previousCtxs, err := lintcontext.CreateContextsWithOptions(options, compareTo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading previous revision from %s", compareTo)
+		}
+		return lintcontext.WithPreviousRevision(lintCtxs, previousCtxs), nil
+	}
+
+	dir, err := os.MkdirTemp("", "kube-linter-compare-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary directory")
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	previousPaths, err := checkoutRevision(compareTo, dir, args)
+	if err != nil {
+		return nil, err
+	}
+	var previousCtxs []lintcontext.LintContext
+	if len(previousPaths) > 0 {
+		previousCtxs, err = lintcontext.CreateContextsWithOptions(options, previousPaths...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading previous revision from git revision %s", compareTo)
+		}
+	}
+	return lintcontext.WithPreviousRevision(lintCtxs, atRevision(previousCtxs, dir, compareTo)), nil
+}
+
+// revisionContext is a lint context of a git revision, whose objects are attributed to paths of the form
+// <revision>:<path in the repository>, since the directory the revision was extracted to is removed after loading.
+type revisionContext struct {
+	lintcontext.LintContext
+	objects []lintcontext.Object
+}
+
+func (c *revisionContext) Objects() []lintcontext.Object {
+	return c.objects
+}
+
+// atRevision returns lint contexts with the objects of the given lint contexts, which were loaded from the git
+// revision rev extracted into dir, attributed to their path in the revision.
+func atRevision(lintCtxs []lintcontext.LintContext, dir, rev string) []lintcontext.LintContext {
+	revisionCtxs := make([]lintcontext.LintContext, 0, len(lintCtxs))
+	for _, lintCtx := range lintCtxs {
+		objects := lintCtx.Objects()
+		revisionCtx := &revisionContext{LintContext: lintCtx, objects: make([]lintcontext.Object, 0, len(objects))}
+		for _, obj := range objects {
+			if relPath, err := filepath.Rel(dir, obj.Metadata.FilePath); err == nil {
+				obj.Metadata.FilePath = rev + ":" + filepath.ToSlash(relPath)
+			}
+			revisionCtx.objects = append(revisionCtx.objects, obj)
+		}
+		revisionCtxs = append(revisionCtxs, revisionCtx)
+	}
+	return revisionCtxs
+}
+
+// checkoutRevision extracts the given git revision of the repository containing the current directory into dir, and
+// returns the paths in dir that correspond to the given paths. Paths that don't exist in the revision are skipped.
+func checkoutRevision(rev, dir string, paths []string) ([]string, error) {
+	if _, err := git("rev-parse", "--verify", "--quiet", rev+"^{commit}"); err != nil {
+		return nil, errors.Errorf("%s is neither an existing path nor a git revision", rev)
+	}
+	topLevelOut, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	topLevel, err := filepath.EvalSymlinks(strings.TrimSpace(string(topLevelOut)))
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving git repository root")
+	}
+	archive, err := git("archive", "--format=tar", rev)
+	if err != nil {
+		return nil, err
+	}
+	if err := extractTar(bytes.NewReader(archive), dir); err != nil {
+		return nil, errors.Wrapf(err, "extracting git revision %s", rev)
+	}
+
+	var previousPaths []string
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving %s", path)
+		}
+		if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+			absPath = resolved
+		}
+		relPath, err := filepath.Rel(topLevel, absPath)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			return nil, errors.Errorf("%s is outside of the git repository %s", path, topLevel)
+		}
+		previousPath := filepath.Join(dir, relPath)
+		if _, err := os.Stat(previousPath); err != nil {
+			continue
+		}
+		previousPaths = append(previousPaths, previousPath)
+	}
+	return previousPaths, nil
+}
+
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "running git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// extractTar extracts the regular files and directories of the given tar archive into dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(filepath.Separator)) {
+			return errors.Errorf("invalid path %s in archive", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr) //nolint:gosec // The archive is created from a local git repository.
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+func TestWithPreviousGitRevision(t *testing.T) {
+	repo := t.TempDir()
+	manifest := filepath.Join(repo, "deploy", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(manifest), 0o755))
+	require.NoError(t, os.WriteFile(manifest, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: old\n"), 0o600))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(repo))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial"},
+	} {
+		_, err := git(args...)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(manifest, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: new\n"), 0o600))
+
+	lintCtxs, err := lintcontext.CreateContexts("deploy")
+	require.NoError(t, err)
+	transitionCtxs, err := withPreviousRevision(lintCtxs, lintcontext.Options{}, "HEAD", []string{"deploy"})
+	require.NoError(t, err)
+	require.Len(t, transitionCtxs, 1)
+
+	removed := transitionCtxs[0].(lintcontext.TransitionContext).RemovedObjects()
+	require.Len(t, removed, 1)
+	assert.Equal(t, "old", removed[0].K8sObject.GetName())
+	// The revision was extracted to a temporary directory, which is gone by now.
+	assert.Equal(t, "HEAD:deploy/config.yaml", removed[0].Metadata.FilePath)
+}
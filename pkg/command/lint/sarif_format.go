@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/owenrumney/go-sarif/v2/sarif"
@@ -27,6 +28,12 @@ Template: {{checkTemplateURL .}}`
 
 	resultMessageTemplateStr = `{{.Report.Diagnostic.Message}}
 object: {{.ObjectName}}`
+
+	// srcRootBaseID is the base URI id that relative artifact locations are resolved against.
+	srcRootBaseID = "SRCROOT"
+
+	// noteLevel is the level of the informational results of run.NoteCheck.
+	noteLevel = "note"
 )
 
 var (
@@ -34,6 +41,15 @@ var (
 		template.FuncMap{"checkTemplateURL": getCheckTemplateURL})
 
 	resultMessageTemplate = common.MustInstantiatePlainTemplate(resultMessageTemplateStr, nil)
+
+	// securitySeverityScores maps check severities to the CVSS-like scores that GitHub expects in the
+	// security-severity rule property.
+	securitySeverityScores = map[config.Severity]string{
+		config.SeverityLow:      "3.0",
+		config.SeverityMedium:   "5.0",
+		config.SeverityHigh:     "7.5",
+		config.SeverityCritical: "9.5",
+	}
 )
 
 // formatLintSarif implements common.SARIFFormat.
@@ -65,6 +81,9 @@ func formatSarif(out io.Writer, result run.Result) error {
 		WithEndTimeUTC(result.Summary.CheckEndTime).
 		// WithWorkingDirectory helps GitHub resolve artifact locations from repo root when their paths are absolute.
 		WithWorkingDirectory(sarif.NewArtifactLocation().WithUri("file://" + cwd))
+	sarifRun.WithOriginalUriBaseIds(map[string]*sarif.ArtifactLocation{
+		srcRootBaseID: sarif.NewArtifactLocation().WithUri("file://" + filepath.ToSlash(cwd) + "/"),
+	})
 
 	for i := range result.Checks {
 		err = addSarifRule(sarifRun, &result.Checks[i])
@@ -80,6 +99,24 @@ func formatSarif(out io.Writer, result run.Result) error {
 		}
 	}
 
+	if hasNotes(result) {
+		addSarifNoteRule(sarifRun)
+	}
+	for i := range result.Notes {
+		err = addSarifResult(sarifRun, cwd, &result.Notes[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	// Suppressed results are reported as well, so that code scanning tools show them as dismissed.
+	for i := range result.Suppressed {
+		err = addSarifResult(sarifRun, cwd, &result.Suppressed[i])
+		if err != nil {
+			return err
+		}
+	}
+
 	return sarifReport.Write(out)
 }
 
@@ -94,7 +131,7 @@ func addSarifRule(sarifRun *sarif.Run, check *config.Check) error {
 		return err
 	}
 
-	sarifRun.AddRule(check.Name).
+	rule := sarifRun.AddRule(check.Name).
 		WithDescription(check.Description).
 		WithFullDescription(sarif.NewMultiformatMessageString(check.Remediation)).
 		WithHelpURI(helpURL).
@@ -105,9 +142,44 @@ func addSarifRule(sarifRun *sarif.Run, check *config.Check) error {
 		// Markdown format for Help seemed to be ignored therefore we only provide the plain text version.
 		WithTextHelp(helpText)
 
+	properties := sarif.Properties{}
+	if tags := check.AllTags(); len(tags) > 0 {
+		// GitHub uses the "tags" property to filter and display alerts.
+		properties["tags"] = tags
+	}
+	if score, ok := securitySeverityScores[check.Severity]; ok {
+		properties["security-severity"] = score
+	}
+	if len(properties) > 0 {
+		rule.WithProperties(properties)
+	}
+
 	return nil
 }
 
+// hasNotes returns whether the result has informational diagnostics of run.NoteCheck, suppressed or not.
+func hasNotes(result run.Result) bool {
+	if len(result.Notes) > 0 {
+		return true
+	}
+	for i := range result.Suppressed {
+		if result.Suppressed[i].Check == run.NoteCheck {
+			return true
+		}
+	}
+	return false
+}
+
+// addSarifNoteRule adds the rule of run.NoteCheck, which is not a check of the registry.
+func addSarifNoteRule(sarifRun *sarif.Run) {
+	sarifRun.AddRule(run.NoteCheck).
+		WithDescription("Indicates parts of a configuration that can only be evaluated when it is applied, and are not linted").
+		WithFullDescription(sarif.NewMultiformatMessageString(run.NoteRemediation)).
+		WithHelpURI(consts.MainURL).
+		WithTextHelp(fmt.Sprintf("Check: %s\nRemediation: %s", run.NoteCheck, run.NoteRemediation)).
+		WithDefaultConfiguration(sarif.NewReportingConfiguration().WithLevel(noteLevel))
+}
+
 func getCheckTemplateURL(check *config.Check) (string, error) {
 	anchor, err := checks.GetTemplateLink(check)
 	if err != nil {
@@ -128,12 +200,10 @@ func renderTemplate(t *template.Template, data interface{}) (string, error) {
 func addSarifResult(sarifRun *sarif.Run, cwd string, report *diagnostic.WithContext) error {
 	sarifLocation := sarif.NewLocation()
 
-	sarifLocation.PhysicalLocation = sarif.NewPhysicalLocation().
-		WithArtifactLocation(sarif.NewArtifactLocation().WithUri(getArtifactURI(cwd, report.Object.Metadata.FilePath))).
-		// We currently assign all errors to the first line in the file otherwise the absent region on the output
-		// does not pass GitHub validation rule GH1003.
-		// TODO: update region location with actual position in the file when it is available.
-		WithRegion(sarif.NewRegion().WithStartLine(1))
+	sarifLocation.PhysicalLocation = getPhysicalLocation(cwd, &report.Object)
+	if line := getDiagnosticLine(report); line > 0 {
+		sarifLocation.PhysicalLocation.WithRegion(sarif.NewRegion().WithStartLine(line))
+	}
 
 	k8sObjectName := report.Object.GetK8sObjectName()
 
@@ -156,13 +226,99 @@ func addSarifResult(sarifRun *sarif.Run, cwd string, report *diagnostic.WithCont
 
 	result := sarif.NewRuleResult(report.Check).
 		WithMessage(sarif.NewTextMessage(messageText))
+	if report.Check == run.NoteCheck {
+		result.WithLevel(noteLevel)
+	}
 	result.AddLocation(sarifLocation)
 
+	for i := range report.Diagnostic.RelatedObjects {
+		related := &report.Diagnostic.RelatedObjects[i]
+		result.AddRelatedLocation(sarif.NewLocationWithPhysicalLocation(getPhysicalLocation(cwd, related)).
+			WithId(i + 1).
+			WithMessage(sarif.NewTextMessage(related.GetK8sObjectName().String())))
+	}
+
+	if fix := getSarifFix(cwd, report); fix != nil {
+		result.Fixes = append(result.Fixes, fix)
+	}
+
+	if report.Suppression != nil {
+		suppression := sarif.NewSuppression("inSource")
+		if report.Suppression.Justification != "" {
+			suppression.WithJustifcation(report.Suppression.Justification)
+		}
+		result.AddSuppression(suppression)
+	}
+
 	sarifRun.AddResult(result)
 
 	return nil
 }
 
+func getPhysicalLocation(cwd string, object *lintcontext.Object) *sarif.PhysicalLocation {
+	return sarif.NewPhysicalLocation().
+		WithArtifactLocation(getArtifactLocation(cwd, object.Metadata.FilePath)).
+		// If the position of the object is unknown, we assign it to the first line in the file, otherwise
+		// the absent region on the output does not pass GitHub validation rule GH1003.
+		WithRegion(sarif.NewRegion().WithStartLine(getStartLine(object)))
+}
+
+func getStartLine(object *lintcontext.Object) int {
+	if object.Metadata.LineNumber > 0 {
+		return object.Metadata.LineNumber
+	}
+	// Rendered objects have no line in the rendered output, but may in their template.
+	if template := object.Metadata.HelmTemplate; template != nil && template.StartLine > 0 {
+		return template.StartLine
+	}
+	if object.Metadata.TemplateLineNumber > 0 {
+		return object.Metadata.TemplateLineNumber
+	}
+	return 1
+}
+
+// getDiagnosticLine returns the line of the file that the diagnostic points at, or 0 if the diagnostic is not
+// specific to a line or the position of the object in its file is unknown.
+func getDiagnosticLine(report *diagnostic.WithContext) int {
+	if report.Diagnostic.Line == 0 || report.Object.Metadata.LineNumber == 0 {
+		return 0
+	}
+	return report.Object.Metadata.LineNumber - 1 + report.Diagnostic.Line
+}
+
+// getSarifFix converts the fix of the diagnostic, if any. Fixes are only converted if the position of the
+// object in its file is known, since replacements are relative to the object's source.
+func getSarifFix(cwd string, report *diagnostic.WithContext) *sarif.Fix {
+	fix := report.Diagnostic.Fix
+	lineNumber := report.Object.Metadata.LineNumber
+	if fix == nil || lineNumber == 0 || len(fix.Replacements) == 0 {
+		return nil
+	}
+	artifactChange := sarif.NewArtifactChange(getArtifactLocation(cwd, report.Object.Metadata.FilePath))
+	for _, replacement := range fix.Replacements {
+		region := sarif.NewRegion().
+			WithStartLine(lineNumber - 1 + replacement.StartLine).
+			WithStartColumn(replacement.StartColumn).
+			WithEndLine(lineNumber - 1 + replacement.EndLine).
+			WithEndColumn(replacement.EndColumn)
+		artifactChange.WithReplacement(sarif.NewReplacement(region).
+			WithInsertedContent(sarif.NewArtifactContent().WithText(replacement.InsertedText)))
+	}
+	return sarif.NewFix().
+		WithDescriptionText(fix.Description).
+		WithArtifactChanges([]*sarif.ArtifactChange{artifactChange})
+}
+
+// getArtifactLocation returns the location of the given path, relative to srcRootBaseID if possible.
+func getArtifactLocation(cwd, path string) *sarif.ArtifactLocation {
+	uri := getArtifactURI(cwd, path)
+	artifactLocation := sarif.NewArtifactLocation().WithUri(uri)
+	if !strings.HasPrefix(uri, "file://") && !filepath.IsAbs(uri) {
+		artifactLocation.WithUriBaseId(srcRootBaseID)
+	}
+	return artifactLocation
+}
+
 // getArtifactURI tries to resolve path relative to cwd; if that fails, tries to get the absolute path with appended
 // `file://` protocol; if that fails, returns the path as-is.
 // GitHub prefers file URIs to be provided relative to the repo root. Assuming that this tool is invoked from the repo
@@ -0,0 +1,169 @@
+package lint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDeploymentObject(name, filePath string, lineNumber int) lintcontext.Object {
+	return lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: filePath, LineNumber: lineNumber},
+		K8sObject: &appsV1.Deployment{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "apps"},
+		},
+	}
+}
+
+func formatTestSarif(t *testing.T, result run.Result) *sarif.Run {
+	var out bytes.Buffer
+	require.NoError(t, formatSarif(&out, result))
+	report, err := sarif.FromBytes(out.Bytes())
+	require.NoError(t, err)
+	require.Len(t, report.Runs, 1)
+	return report.Runs[0]
+}
+
+func startLine(location *sarif.Location) int {
+	return *location.PhysicalLocation.Region.StartLine
+}
+
+func TestFormatSarif(t *testing.T) {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	serviceAccount := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: "deploy/service-account.yaml", LineNumber: 5},
+		K8sObject: &coreV1.ServiceAccount{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "apps"},
+		},
+	}
+	fix := &diagnostic.Fix{
+		Description: "Quote the value",
+		Replacements: []diagnostic.Replacement{
+			{StartLine: 3, StartColumn: 10, EndLine: 4, EndColumn: 2, InsertedText: `"on"`},
+		},
+	}
+	result := run.Result{
+		Checks: []config.Check{
+			{Name: "host-network", Template: "host-network", Severity: config.SeverityHigh, Tags: []string{"security"}},
+			{Name: "unset-severity", Template: "host-network"},
+		},
+		Reports: []diagnostic.WithContext{
+			{
+				Check:  "host-network",
+				Object: newDeploymentObject("positioned", "deploy/app.yaml", 10),
+				Diagnostic: diagnostic.Diagnostic{
+					Message:        "uses the host network",
+					Line:           3,
+					Fix:            fix,
+					RelatedObjects: []lintcontext.Object{serviceAccount},
+				},
+			},
+			{
+				Check:      "host-network",
+				Object:     newDeploymentObject("unpositioned", "deploy/rendered.yaml", 0),
+				Diagnostic: diagnostic.Diagnostic{Message: "uses the host network", Line: 3, Fix: fix},
+			},
+		},
+		Suppressed: []diagnostic.WithContext{
+			{
+				Check:       "host-network",
+				Object:      newDeploymentObject("suppressed", "deploy/app.yaml", 20),
+				Diagnostic:  diagnostic.Diagnostic{Message: "uses the host network"},
+				Suppression: &diagnostic.Suppression{Justification: "Needs the node's interfaces"},
+			},
+		},
+	}
+	sarifRun := formatTestSarif(t, result)
+
+	require.Contains(t, sarifRun.OriginalUriBaseIDs, srcRootBaseID)
+	assert.Equal(t, "file://"+filepath.ToSlash(cwd)+"/", *sarifRun.OriginalUriBaseIDs[srcRootBaseID].URI)
+
+	require.Len(t, sarifRun.Tool.Driver.Rules, 2)
+	assert.Equal(t, "7.5", sarifRun.Tool.Driver.Rules[0].Properties["security-severity"])
+	assert.NotContains(t, sarifRun.Tool.Driver.Rules[1].Properties, "security-severity")
+
+	require.Len(t, sarifRun.Results, 3)
+
+	// The diagnostic line and the fix are relative to the object's source, which starts at line 10.
+	positioned := sarifRun.Results[0]
+	require.Len(t, positioned.Locations, 1)
+	artifactLocation := positioned.Locations[0].PhysicalLocation.ArtifactLocation
+	assert.Equal(t, "deploy/app.yaml", *artifactLocation.URI)
+	assert.Equal(t, srcRootBaseID, *artifactLocation.URIBaseId)
+	assert.Equal(t, 12, startLine(positioned.Locations[0]))
+	require.Len(t, positioned.Fixes, 1)
+	require.Len(t, positioned.Fixes[0].ArtifactChanges, 1)
+	change := positioned.Fixes[0].ArtifactChanges[0]
+	assert.Equal(t, "deploy/app.yaml", *change.ArtifactLocation.URI)
+	require.Len(t, change.Replacements, 1)
+	region := change.Replacements[0].DeletedRegion
+	assert.Equal(t, 12, *region.StartLine)
+	assert.Equal(t, 10, *region.StartColumn)
+	assert.Equal(t, 13, *region.EndLine)
+	assert.Equal(t, 2, *region.EndColumn)
+	assert.Equal(t, `"on"`, *change.Replacements[0].InsertedContent.Text)
+	require.Len(t, positioned.RelatedLocations, 1)
+	related := positioned.RelatedLocations[0]
+	assert.Equal(t, uint(1), *related.Id)
+	assert.Equal(t, "deploy/service-account.yaml", *related.PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 5, startLine(related))
+	assert.Equal(t, "apps/app /v1, Kind=ServiceAccount", *related.Message.Text)
+	assert.Empty(t, positioned.Suppressions)
+
+	// Without the position of the object, the result points at the first line, and the fix can't be located.
+	unpositioned := sarifRun.Results[1]
+	assert.Equal(t, 1, startLine(unpositioned.Locations[0]))
+	assert.Empty(t, unpositioned.Fixes)
+
+	suppressed := sarifRun.Results[2]
+	assert.Equal(t, 20, startLine(suppressed.Locations[0]))
+	require.Len(t, suppressed.Suppressions, 1)
+	assert.Equal(t, "inSource", suppressed.Suppressions[0].Kind)
+	assert.Equal(t, "Needs the node's interfaces", *suppressed.Suppressions[0].Justification)
+}
+
+func TestFormatSarifList(t *testing.T) {
+	// The items of a List share the position and the source of the List, so their lines are relative to it.
+	first := newDeploymentObject("first", "deploy/list.yaml", 4)
+	second := newDeploymentObject("second", "deploy/list.yaml", 4)
+	result := run.Result{
+		Checks: []config.Check{{Name: "host-network", Template: "host-network"}},
+		Reports: []diagnostic.WithContext{
+			{Check: "host-network", Object: first, Diagnostic: diagnostic.Diagnostic{Message: "uses the host network", Line: 8}},
+			{Check: "host-network", Object: second, Diagnostic: diagnostic.Diagnostic{Message: "uses the host network", Line: 20}},
+			{Check: "host-network", Object: second, Diagnostic: diagnostic.Diagnostic{Message: "uses the host network"}},
+		},
+	}
+	sarifRun := formatTestSarif(t, result)
+
+	require.Len(t, sarifRun.Results, 3)
+	for i, expected := range []struct {
+		name string
+		line int
+	}{
+		{name: "first", line: 11},
+		{name: "second", line: 23},
+		{name: "second", line: 4},
+	} {
+		location := sarifRun.Results[i].Locations[0]
+		assert.Equal(t, "deploy/list.yaml", *location.PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, expected.line, startLine(location))
+		assert.Equal(t, expected.name, *location.LogicalLocations[0].Name)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"golang.stackrox.io/kube-linter/pkg/command/checks"
+	"golang.stackrox.io/kube-linter/pkg/command/compliance"
 	"golang.stackrox.io/kube-linter/pkg/command/lint"
 	"golang.stackrox.io/kube-linter/pkg/command/templates"
 	"golang.stackrox.io/kube-linter/pkg/command/version"
@@ -31,6 +32,7 @@ func Command() *cobra.Command {
 	}
 	c.AddCommand(
 		checks.Command(),
+		compliance.Command(),
 		lint.Command(),
 		templates.Command(),
 		version.Command(),
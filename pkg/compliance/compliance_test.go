@@ -0,0 +1,104 @@
+package compliance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+func TestFrameworksWellFormed(t *testing.T) {
+	frameworks, err := List()
+	require.NoError(t, err)
+	require.NotEmpty(t, frameworks)
+
+	builtInChecks, err := builtinchecks.List()
+	require.NoError(t, err)
+	checkNames := make(map[string]struct{})
+	for _, check := range builtInChecks {
+		checkNames[check.Name] = struct{}{}
+	}
+
+	for _, framework := range frameworks {
+		t.Run(framework.Name, func(t *testing.T) {
+			assert.NotEmpty(t, framework.Title)
+			seenIDs := make(map[string]struct{})
+			for _, control := range framework.Controls {
+				assert.NotContains(t, seenIDs, control.ID, "duplicate control")
+				seenIDs[control.ID] = struct{}{}
+				assert.NotEmpty(t, control.Title, "control %s has no title", control.ID)
+				assert.True(t, len(control.Checks) > 0 != (control.NotCoveredReason != ""),
+					"control %s must have either checks or a reason why it is not covered", control.ID)
+				for _, check := range control.Checks {
+					assert.Contains(t, checkNames, check, "control %s references unknown check", control.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestCISTagsMatchControls(t *testing.T) {
+	framework, err := Get("cis-1.8")
+	require.NoError(t, err)
+	controlChecks := make(map[string][]string)
+	for _, control := range framework.Controls {
+		controlChecks[control.ID] = control.Checks
+	}
+
+	builtInChecks, err := builtinchecks.List()
+	require.NoError(t, err)
+	for _, check := range builtInChecks {
+		for _, tag := range check.Tags {
+			if id := strings.TrimPrefix(tag, "cis:"); id != tag {
+				assert.Contains(t, controlChecks[id], check.Name, "check is tagged %s but not mapped to that control", tag)
+			}
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	framework := &Framework{
+		Name: "test",
+		Tag:  "test",
+		Controls: []Control{
+			{ID: "1", Checks: []string{"a", "b"}},
+			{ID: "2", Checks: []string{"c"}},
+			{ID: "3", NotCoveredReason: "reason"},
+			{ID: "4", Checks: []string{"excluded"}},
+			{ID: "5", NotCoveredReason: "No built-in check."},
+		},
+	}
+	report := Evaluate(framework, run.Result{
+		Checks: []config.Check{
+			{Name: "a"},
+			{Name: "b"},
+			{Name: "c"},
+			{Name: "custom", Tags: []string{"test:5"}},
+		},
+		Reports: []diagnostic.WithContext{{Check: "b"}, {Check: "d"}, {Check: "custom"}},
+	})
+
+	require.Len(t, report.Controls, 5)
+	assert.Equal(t, ControlFailed, report.Controls[0].Status)
+	assert.Len(t, report.Controls[0].Reports, 1)
+	assert.Equal(t, ControlPassed, report.Controls[1].Status)
+	assert.Equal(t, ControlNotCovered, report.Controls[2].Status)
+	assert.Equal(t, "reason", report.Controls[2].Control.NotCoveredReason)
+	// The check of the control was not run, e.g. because the configuration excludes it.
+	assert.Equal(t, ControlNotCovered, report.Controls[3].Status)
+	assert.Equal(t, "The checks of the control are not enabled by the configuration: excluded.", report.Controls[3].Control.NotCoveredReason)
+	// Custom checks are mapped to controls by their tags.
+	assert.Equal(t, ControlFailed, report.Controls[4].Status)
+	assert.Len(t, report.Controls[4].Reports, 1)
+	assert.Equal(t, ReportSummary{Passed: 1, Failed: 2, NotCovered: 2}, report.Summary)
+}
+
+func TestGetUnknownFramework(t *testing.T) {
+	_, err := Get("does-not-exist")
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package compliance
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+var (
+	//go:embed frameworks
+	yamlFiles embed.FS
+
+	loadOnce   sync.Once
+	frameworks []Framework
+	loadErr    error
+)
+
+// A Framework is a compliance framework, such as a benchmark or a hardening guide,
+// along with a mapping from its controls to checks.
+type Framework struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	URL   string `json:"url,omitempty"`
+	// Tag, if set, also maps the checks tagged <tag>:<control ID> to the control with that ID, e.g. custom checks
+	// tagged cis:5.1.8.
+	Tag      string    `json:"tag,omitempty"`
+	Controls []Control `json:"controls"`
+}
+
+// A Control is a single control of a compliance framework.
+type Control struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Checks are the names of the checks that together evaluate the control.
+	Checks []string `json:"checks,omitempty"`
+	// NotCoveredReason explains why a control without checks cannot be evaluated.
+	NotCoveredReason string `json:"notCoveredReason,omitempty"`
+}
+
+// Checks returns the names of all checks mapped to the framework's controls, sorted and deduplicated.
+func (f *Framework) Checks() []string {
+	seen := make(map[string]struct{})
+	var checks []string
+	for _, control := range f.Controls {
+		for _, check := range control.Checks {
+			if _, ok := seen[check]; ok {
+				continue
+			}
+			seen[check] = struct{}{}
+			checks = append(checks, check)
+		}
+	}
+	sort.Strings(checks)
+	return checks
+}
+
+// ControlChecks returns the names of the given checks that evaluate the control, i.e. the checks mapped to it and
+// the checks tagged with its ID.
+func (f *Framework) ControlChecks(control Control, checks []config.Check) []string {
+	var names []string
+	for i := range checks {
+		if f.evaluates(control, &checks[i]) {
+			names = append(names, checks[i].Name)
+		}
+	}
+	return names
+}
+
+// Evaluates returns whether the given check evaluates any of the controls of the framework.
+func (f *Framework) Evaluates(check *config.Check) bool {
+	for _, control := range f.Controls {
+		if f.evaluates(control, check) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Framework) evaluates(control Control, check *config.Check) bool {
+	for _, name := range control.Checks {
+		if name == check.Name {
+			return true
+		}
+	}
+	return f.Tag != "" && check.HasAnyTag(f.Tag+":"+control.ID)
+}
+
+// List lists the embedded compliance frameworks.
+func List() ([]Framework, error) {
+	loadOnce.Do(func() {
+		fileEntries, err := yamlFiles.ReadDir("frameworks")
+		if err != nil {
+			loadErr = errors.Wrap(err, "reading embedded yaml files")
+			return
+		}
+		for _, entry := range fileEntries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+				loadErr = errors.Errorf("found unexpected entry %s in frameworks directory", entry.Name())
+				return
+			}
+			// Do NOT use filepath.Join here, because embed always uses `/` as the separator,
+			// irrespective of the OS we're running.
+			contents, err := yamlFiles.ReadFile(fmt.Sprintf("frameworks/%s", entry.Name()))
+			if err != nil {
+				loadErr = errors.Wrapf(err, "loading file %s", entry.Name())
+				return
+			}
+			var framework Framework
+			if err := yaml.Unmarshal(contents, &framework); err != nil {
+				loadErr = errors.Wrapf(err, "unmarshalling framework from %s", entry.Name())
+				return
+			}
+			frameworks = append(frameworks, framework)
+		}
+	})
+	if loadErr != nil {
+		return nil, errors.Wrap(loadErr, "UNEXPECTED: failed to load compliance frameworks")
+	}
+	return frameworks, nil
+}
+
+// Get returns the framework with the given name.
+func Get(name string) (*Framework, error) {
+	all, err := List()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(all))
+	for i := range all {
+		if all[i].Name == name {
+			return &all[i], nil
+		}
+		names = append(names, all[i].Name)
+	}
+	return nil, errors.Errorf("unknown framework %q, must be one of %v", name, names)
+}
@@ -0,0 +1,80 @@
+package compliance
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// ControlStatus is the outcome of evaluating a single control.
+type ControlStatus string
+
+const (
+	// ControlPassed means that none of the control's checks reported a violation.
+	ControlPassed ControlStatus = "pass"
+	// ControlFailed means that at least one of the control's checks reported a violation.
+	ControlFailed ControlStatus = "fail"
+	// ControlNotCovered means that the control has no checks, so it cannot be evaluated from manifests, or that none
+	// of its checks were run.
+	ControlNotCovered ControlStatus = "not-covered"
+)
+
+// ControlResult is the result of evaluating a single control.
+type ControlResult struct {
+	Control Control
+	Status  ControlStatus
+	Reports []diagnostic.WithContext `json:",omitempty"`
+}
+
+// Report is a per-control compliance report.
+type Report struct {
+	Framework Framework
+	Controls  []ControlResult
+	Summary   ReportSummary
+}
+
+// ReportSummary holds the number of controls per status.
+type ReportSummary struct {
+	Passed            int
+	Failed            int
+	NotCovered        int
+	KubeLinterVersion string
+}
+
+// Evaluate builds a compliance report for the framework from the result of a run of the framework's checks that
+// are enabled. Controls are evaluated by the checks of the run that are mapped or tagged to them.
+func Evaluate(framework *Framework, result run.Result) Report {
+	reportsByCheck := make(map[string][]diagnostic.WithContext)
+	for _, report := range result.Reports {
+		reportsByCheck[report.Check] = append(reportsByCheck[report.Check], report)
+	}
+
+	report := Report{Framework: *framework}
+	report.Summary.KubeLinterVersion = result.Summary.KubeLinterVersion
+	for _, control := range framework.Controls {
+		controlResult := ControlResult{Control: control}
+		checks := framework.ControlChecks(control, result.Checks)
+		for _, check := range checks {
+			controlResult.Reports = append(controlResult.Reports, reportsByCheck[check]...)
+		}
+		switch {
+		case len(checks) == 0:
+			if len(control.Checks) > 0 {
+				controlResult.Control.NotCoveredReason = fmt.Sprintf("The checks of the control are not enabled by the configuration: %s.",
+					strings.Join(control.Checks, ", "))
+			}
+			controlResult.Status = ControlNotCovered
+			report.Summary.NotCovered++
+		case len(controlResult.Reports) > 0:
+			controlResult.Status = ControlFailed
+			report.Summary.Failed++
+		default:
+			controlResult.Status = ControlPassed
+			report.Summary.Passed++
+		}
+		report.Controls = append(report.Controls, controlResult)
+	}
+	return report
+}
@@ -1,13 +1,53 @@
 package config
 
+// Severity is the severity of violations of a check.
+type Severity string
+
+// The valid severities, in increasing order.
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// Severities lists the valid severities, in increasing order.
+var Severities = []Severity{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
 // A Check represents a single check. It is serializable.
 type Check struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Remediation string                 `json:"remediation"`
-	Scope       *ObjectKindsDesc       `json:"scope"`
-	Template    string                 `json:"template"`
-	Params      map[string]interface{} `json:"params,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Remediation string `json:"remediation"`
+	// Category is the broad area the check belongs to, e.g. security, reliability or best-practice.
+	Category string `json:"category,omitempty"`
+	// Tags are free-form labels used to select checks, e.g. rbac, networking or cis:5.2.1.
+	Tags []string `json:"tags,omitempty"`
+	// Severity is the severity of violations of the check, one of low, medium, high or critical.
+	Severity Severity               `json:"severity,omitempty"`
+	Scope    *ObjectKindsDesc       `json:"scope"`
+	Template string                 `json:"template"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+}
+
+// AllTags returns the tags of the check, including its category (if set) as the first element.
+func (c *Check) AllTags() []string {
+	if c.Category == "" {
+		return c.Tags
+	}
+	return append([]string{c.Category}, c.Tags...)
+}
+
+// HasAnyTag returns whether the check's category or tags contain any of the given tags.
+func (c *Check) HasAnyTag(tags ...string) bool {
+	for _, checkTag := range c.AllTags() {
+		for _, tag := range tags {
+			if checkTag == tag {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // ObjectKindsDesc describes a list of supported object kinds for a check template.
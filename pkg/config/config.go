@@ -26,6 +26,13 @@ type ChecksConfig struct {
 	// Exclude wins.
 	// +flagName=include
 	Include []string `json:"include"`
+	// IncludeTags is a list of tags. Checks with a category or tag in the list are included.
+	// +flagName=include-tags
+	IncludeTags []string `json:"includeTags"`
+	// ExcludeTags is a list of tags. Checks with a category or tag in the list are excluded.
+	// ExcludeTags wins over IncludeTags, but not over Include.
+	// +flagName=exclude-tags
+	ExcludeTags []string `json:"excludeTags"`
 }
 
 // Config represents the config file format.
@@ -33,6 +40,13 @@ type Config struct {
 	// +flagName=-
 	CustomChecks []Check      `json:"customChecks,omitempty"`
 	Checks       ChecksConfig `json:"checks,omitempty"`
+	// NodePools describes the nodes of the cluster the objects are deployed to.
+	// +flagName=-
+	NodePools []NodePool `json:"nodePools,omitempty"`
+	// Helm configures how Helm charts are rendered.
+	Helm HelmConfig `json:"helm,omitempty"`
+	// Ytt configures which directories are ytt configurations, and how they are rendered.
+	Ytt YttConfig `json:"ytt,omitempty"`
 }
 
 // Defines the list of default config filenames to check if parameter isn't passed in
@@ -73,6 +87,10 @@ func Load(v *viper.Viper, configPath string) (Config, error) {
 	var conf Config
 	err := v.Unmarshal(&conf, viper.DecoderConfigOption(func(config *mapstructure.DecoderConfig) {
 		config.TagName = "json"
+		config.DecodeHook = mapstructure.ComposeDecodeHookFunc(
+			config.DecodeHook,
+			quantityDecodeHook,
+		)
 	}))
 	if err != nil {
 		return Config{}, errors.Wrap(err, "unmarshalling config File")
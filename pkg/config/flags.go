@@ -27,4 +27,44 @@ func AddFlags(c *cobra.Command, v *viper.Viper) {
 	if err := v.BindPFlag("checks.include", c.Flags().Lookup("include")); err != nil {
 		panic(err)
 	}
+	c.Flags().StringSlice("include-tags", nil, "IncludeTags is a list of tags. Checks with a category or tag in the list are included.")
+	if err := v.BindPFlag("checks.includeTags", c.Flags().Lookup("include-tags")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("exclude-tags", nil, "ExcludeTags is a list of tags. Checks with a category or tag in the list are excluded. ExcludeTags wins over IncludeTags, but not over Include.")
+	if err := v.BindPFlag("checks.excludeTags", c.Flags().Lookup("exclude-tags")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-cluster-profile", "", "ClusterProfile is the name of a built-in cluster profile, e.g. kubernetes-1.26 or openshift-4.12, whose Kubernetes version and API versions are the default capabilities.")
+	if err := v.BindPFlag("helm.clusterProfile", c.Flags().Lookup("helm-cluster-profile")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-kube-version", "", "KubeVersion is the version of Kubernetes that charts are rendered for, as .Capabilities.KubeVersion.")
+	if err := v.BindPFlag("helm.kubeVersion", c.Flags().Lookup("helm-kube-version")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("helm-api-versions", nil, "APIVersions are API versions that are available in addition to the default ones or those of the cluster profile, as .Capabilities.APIVersions, e.g. monitoring.coreos.com/v1 or monitoring.coreos.com/v1/ServiceMonitor.")
+	if err := v.BindPFlag("helm.apiVersions", c.Flags().Lookup("helm-api-versions")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-lookup-dir", "", "LookupDirectory is a directory of YAML files whose objects the lookup function of templates returns. Without it, lookup returns no objects.")
+	if err := v.BindPFlag("helm.lookupDirectory", c.Flags().Lookup("helm-lookup-dir")); err != nil {
+		panic(err)
+	}
+	c.Flags().String("helm-post-renderer", "", "PostRenderer is the path of an executable that the rendered output of charts is piped through before it is linted, like the --post-renderer flag of helm.")
+	if err := v.BindPFlag("helm.postRenderer", c.Flags().Lookup("helm-post-renderer")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("helm-post-renderer-args", nil, "PostRendererArgs are the arguments passed to the post-renderer.")
+	if err := v.BindPFlag("helm.postRendererArgs", c.Flags().Lookup("helm-post-renderer-args")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("ytt", nil, "Directories are ytt configuration directories, which are rendered with ytt and linted as one context each, instead of their YAML files being linted as they are.")
+	if err := v.BindPFlag("ytt.directories", c.Flags().Lookup("ytt")); err != nil {
+		panic(err)
+	}
+	c.Flags().StringSlice("ytt-data-values-file", nil, "DataValuesFiles are files of data values that the ytt configurations are rendered with, like the --data-values-file flag of ytt.")
+	if err := v.BindPFlag("ytt.dataValuesFiles", c.Flags().Lookup("ytt-data-values-file")); err != nil {
+		panic(err)
+	}
 }
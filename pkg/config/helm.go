@@ -0,0 +1,24 @@
+package config
+
+// HelmConfig configures how Helm charts are rendered. Options that are not set default to the capabilities of the
+// cluster profile, if any, or else to those Helm assumes when it renders charts without a cluster, like helm template does.
+type HelmConfig struct {
+	// ClusterProfile is the name of a built-in cluster profile, e.g. kubernetes-1.26 or openshift-4.12, whose Kubernetes version and API versions are the default capabilities.
+	// +flagName=helm-cluster-profile
+	ClusterProfile string `json:"clusterProfile,omitempty"`
+	// KubeVersion is the version of Kubernetes that charts are rendered for, as .Capabilities.KubeVersion.
+	// +flagName=helm-kube-version
+	KubeVersion string `json:"kubeVersion,omitempty"`
+	// APIVersions are API versions that are available in addition to the default ones or those of the cluster profile, as .Capabilities.APIVersions, e.g. monitoring.coreos.com/v1 or monitoring.coreos.com/v1/ServiceMonitor.
+	// +flagName=helm-api-versions
+	APIVersions []string `json:"apiVersions,omitempty"`
+	// LookupDirectory is a directory of YAML files whose objects the lookup function of templates returns. Without it, lookup returns no objects.
+	// +flagName=helm-lookup-dir
+	LookupDirectory string `json:"lookupDirectory,omitempty"`
+	// PostRenderer is the path of an executable that the rendered output of charts is piped through before it is linted, like the --post-renderer flag of helm.
+	// +flagName=helm-post-renderer
+	PostRenderer string `json:"postRenderer,omitempty"`
+	// PostRendererArgs are the arguments passed to the post-renderer.
+	// +flagName=helm-post-renderer-args
+	PostRendererArgs []string `json:"postRendererArgs,omitempty"`
+}
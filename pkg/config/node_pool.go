@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// A NodePool describes a group of identical nodes of the cluster the objects are deployed to,
+// which checks use to simulate the scheduling of pods.
+type NodePool struct {
+	// Name is the name of the pool. Its nodes are named after it, and get it as value of the
+	// kubernetes.io/hostname label, followed by the index of the node.
+	Name string `json:"name"`
+	// Count is the number of nodes in the pool. It defaults to 1.
+	Count int `json:"count,omitempty"`
+	// Allocatable is the amount of each resource of a node that is available to pods, e.g. cpu, memory and pods.
+	// cpu, memory and ephemeral-storage are not limited if they aren't listed, pods defaults to 110, and other
+	// resources, such as GPUs, are only available if they are listed.
+	Allocatable coreV1.ResourceList `json:"allocatable,omitempty"`
+	// Labels are the labels of the nodes.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are the taints of the nodes.
+	Taints []coreV1.Taint `json:"taints,omitempty"`
+	// Zones are the zones the nodes are spread across, in a round-robin fashion. Each node gets
+	// the topology.kubernetes.io/zone label of its zone.
+	Zones []string `json:"zones,omitempty"`
+}
+
+var quantityType = reflect.TypeOf(resource.Quantity{})
+
+// quantityDecodeHook decodes resource quantities, such as the allocatable resources of node pools,
+// from strings and numbers.
+func quantityDecodeHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != quantityType {
+		return data, nil
+	}
+	switch from.Kind() {
+	case reflect.String:
+		return resource.ParseQuantity(data.(string))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return resource.ParseQuantity(fmt.Sprint(data))
+	}
+	return data, nil
+}
@@ -0,0 +1,11 @@
+package config
+
+// YttConfig configures which directories are Carvel ytt configurations, and how they are rendered.
+type YttConfig struct {
+	// Directories are ytt configuration directories, which are rendered with ytt and linted as one context each, instead of their YAML files being linted as they are.
+	// +flagName=ytt
+	Directories []string `json:"directories,omitempty"`
+	// DataValuesFiles are files of data values that the ytt configurations are rendered with, like the --data-values-file flag of ytt.
+	// +flagName=ytt-data-values-file
+	DataValuesFiles []string `json:"dataValuesFiles,omitempty"`
+}
@@ -39,6 +39,27 @@ func GetEnabledChecksAndValidate(cfg *config.Config, checkRegistry checkregistry
 	for _, check := range cfg.CustomChecks {
 		enabledChecks.Add(check.Name)
 	}
+	if len(cfg.Checks.IncludeTags) > 0 {
+		builtInChecks, err := builtinchecks.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, checks := range [][]config.Check{builtInChecks, cfg.CustomChecks} {
+			for i := range checks {
+				if checks[i].HasAnyTag(cfg.Checks.IncludeTags...) {
+					enabledChecks.Add(checks[i].Name)
+				}
+			}
+		}
+	}
+	// Checks that are selected by name take precedence over those selected by tag.
+	if len(cfg.Checks.ExcludeTags) > 0 {
+		for check := range enabledChecks {
+			if instantiated := checkRegistry.Load(check); instantiated != nil && instantiated.Spec.HasAnyTag(cfg.Checks.ExcludeTags...) {
+				enabledChecks.Remove(check)
+			}
+		}
+	}
 	enabledChecks.AddAll(cfg.Checks.Include...)
 	enabledChecks.RemoveAll(cfg.Checks.Exclude...)
 
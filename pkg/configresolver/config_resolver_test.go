@@ -0,0 +1,123 @@
+package configresolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/internal/defaultchecks"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+
+	// Register templates
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+)
+
+func TestGetEnabledChecksAndValidate(t *testing.T) {
+	customChecks := []config.Check{
+		{
+			Name:     "team-host-network",
+			Template: "host-network",
+			Category: "security",
+			Tags:     []string{"team"},
+			Scope:    &config.ObjectKindsDesc{ObjectKinds: []string{"DeploymentLike"}},
+		},
+		{
+			Name:     "untagged-host-network",
+			Template: "host-network",
+			Scope:    &config.ObjectKindsDesc{ObjectKinds: []string{"DeploymentLike"}},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name        string
+		checks      config.ChecksConfig
+		custom      bool
+		contains    []string
+		notContains []string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name:     "defaults and included tags",
+			checks:   config.ChecksConfig{IncludeTags: []string{"rbac"}},
+			contains: append(defaultchecks.List.AsSlice(), "access-to-secrets", "cluster-admin-role-binding"),
+		},
+		{
+			name:        "excluded tags remove defaults",
+			checks:      config.ChecksConfig{ExcludeTags: []string{"security"}},
+			contains:    []string{"latest-tag", "mismatching-selector"},
+			notContains: []string{"host-network", "privileged-container"},
+		},
+		{
+			name:        "excluded tags win over included tags",
+			checks:      config.ChecksConfig{DoNotAutoAddDefaults: true, IncludeTags: []string{"rbac"}, ExcludeTags: []string{"security"}},
+			contains:    []string{"non-existent-service-account"},
+			notContains: []string{"access-to-secrets"},
+		},
+		{
+			name:     "explicit include wins over excluded tags",
+			checks:   config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"privileged-container"}, ExcludeTags: []string{"security"}},
+			expected: []string{"privileged-container"},
+		},
+		{
+			name:        "explicit exclude wins over included tags",
+			checks:      config.ChecksConfig{DoNotAutoAddDefaults: true, IncludeTags: []string{"rbac"}, Exclude: []string{"access-to-secrets"}},
+			contains:    []string{"cluster-admin-role-binding"},
+			notContains: []string{"access-to-secrets"},
+		},
+		{
+			name:     "custom checks",
+			checks:   config.ChecksConfig{DoNotAutoAddDefaults: true},
+			custom:   true,
+			expected: []string{"team-host-network", "untagged-host-network"},
+		},
+		{
+			name:     "custom checks with excluded tags",
+			checks:   config.ChecksConfig{DoNotAutoAddDefaults: true, ExcludeTags: []string{"team"}},
+			custom:   true,
+			expected: []string{"untagged-host-network"},
+		},
+		{
+			name:        "excluded categories win over included tags of custom checks",
+			checks:      config.ChecksConfig{DoNotAutoAddDefaults: true, IncludeTags: []string{"team"}, ExcludeTags: []string{"security"}},
+			custom:      true,
+			expected:    []string{"untagged-host-network"},
+			notContains: []string{"team-host-network"},
+		},
+		{
+			name:        "unknown check",
+			checks:      config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"unknown"}},
+			expectedErr: `check "unknown" not found`,
+		},
+	} {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			cfg := config.Config{Checks: c.checks}
+			if c.custom {
+				cfg.CustomChecks = append([]config.Check(nil), customChecks...)
+			}
+			registry := checkregistry.New()
+			require.NoError(t, builtinchecks.LoadInto(registry))
+			require.NoError(t, LoadCustomChecksInto(&cfg, registry))
+
+			enabledChecks, err := GetEnabledChecksAndValidate(&cfg, registry)
+			if c.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if c.expected != nil {
+				assert.Equal(t, c.expected, enabledChecks)
+			}
+			for _, check := range c.contains {
+				assert.Contains(t, enabledChecks, check)
+			}
+			for _, check := range c.notContains {
+				assert.NotContains(t, enabledChecks, check)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package defaults
+
+import (
+	"reflect"
+
+	ocsAppsV1 "github.com/openshift/api/apps/v1"
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	batchV1 "k8s.io/api/batch/v1"
+	batchV1Beta1 "k8s.io/api/batch/v1beta1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultServiceAccountName = "default"
+)
+
+// Admission applies the defaults that the admission plugins of the API server set on the pods created in a namespace,
+// based on the LimitRanges and ServiceAccounts of the namespace.
+type Admission struct {
+	limitRanges     map[string][]*coreV1.LimitRange
+	serviceAccounts map[string]map[string]*coreV1.ServiceAccount
+}
+
+// NewAdmission returns an Admission that uses the LimitRanges and ServiceAccounts among the given objects, which should
+// have had their defaults applied already. Objects without a namespace are considered to be in the same namespace.
+func NewAdmission(objs ...k8sutil.Object) *Admission {
+	a := &Admission{
+		limitRanges:     make(map[string][]*coreV1.LimitRange),
+		serviceAccounts: make(map[string]map[string]*coreV1.ServiceAccount),
+	}
+	for _, obj := range objs {
+		switch obj := obj.(type) {
+		case *coreV1.LimitRange:
+			a.limitRanges[obj.Namespace] = append(a.limitRanges[obj.Namespace], obj)
+		case *coreV1.ServiceAccount:
+			if a.serviceAccounts[obj.Namespace] == nil {
+				a.serviceAccounts[obj.Namespace] = make(map[string]*coreV1.ServiceAccount)
+			}
+			a.serviceAccounts[obj.Namespace][obj.Name] = obj
+		}
+	}
+	return a
+}
+
+// Apply applies the admission defaults to the pod spec of the given object, in place. Objects without a pod spec are
+// left unchanged.
+func (a *Admission) Apply(obj k8sutil.Object) {
+	podSpec := mutablePodSpec(obj)
+	if podSpec == nil {
+		return
+	}
+	for _, limitRange := range a.limitRanges[obj.GetNamespace()] {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != coreV1.LimitTypeContainer {
+				continue
+			}
+			for i := range podSpec.InitContainers {
+				setLimitRangeItemDefaults(&podSpec.InitContainers[i].Resources, item)
+			}
+			for i := range podSpec.Containers {
+				setLimitRangeItemDefaults(&podSpec.Containers[i].Resources, item)
+			}
+		}
+	}
+	if podSpec.AutomountServiceAccountToken == nil {
+		automount := true
+		if serviceAccount := a.serviceAccounts[obj.GetNamespace()][serviceAccountName(podSpec)]; serviceAccount != nil &&
+			serviceAccount.AutomountServiceAccountToken != nil {
+			automount = *serviceAccount.AutomountServiceAccountToken
+		}
+		podSpec.AutomountServiceAccountToken = pointers.Bool(automount)
+	}
+}
+
+func setLimitRangeItemDefaults(resources *coreV1.ResourceRequirements, item coreV1.LimitRangeItem) {
+	if len(item.Default) > 0 {
+		if resources.Limits == nil {
+			resources.Limits = make(coreV1.ResourceList)
+		}
+		addMissingResources(resources.Limits, item.Default)
+	}
+	if len(item.DefaultRequest) > 0 {
+		if resources.Requests == nil {
+			resources.Requests = make(coreV1.ResourceList)
+		}
+		addMissingResources(resources.Requests, item.DefaultRequest)
+	}
+}
+
+func serviceAccountName(podSpec *coreV1.PodSpec) string {
+	switch {
+	case podSpec.ServiceAccountName != "":
+		return podSpec.ServiceAccountName
+	case podSpec.DeprecatedServiceAccount != "":
+		return podSpec.DeprecatedServiceAccount
+	}
+	return defaultServiceAccountName
+}
+
+// mutablePodSpec returns a pointer to the pod spec of the given object, or nil if it has none.
+func mutablePodSpec(obj k8sutil.Object) *coreV1.PodSpec {
+	switch obj := obj.(type) {
+	case *coreV1.Pod:
+		return &obj.Spec
+	case *coreV1.PodTemplate:
+		return &obj.Template.Spec
+	case *batchV1.CronJob:
+		return &obj.Spec.JobTemplate.Spec.Template.Spec
+	case *batchV1Beta1.CronJob:
+		return &obj.Spec.JobTemplate.Spec.Template.Spec
+	case *ocsAppsV1.DeploymentConfig:
+		if obj.Spec.Template == nil {
+			return nil
+		}
+		return &obj.Spec.Template.Spec
+	}
+	objValue := reflect.Indirect(reflect.ValueOf(obj))
+	if objValue.Kind() != reflect.Struct {
+		return nil
+	}
+	spec := objValue.FieldByName("Spec")
+	if !spec.IsValid() || spec.Kind() != reflect.Struct {
+		return nil
+	}
+	template := spec.FieldByName("Template")
+	if !template.IsValid() {
+		return nil
+	}
+	if template.Kind() == reflect.Ptr {
+		if template.IsNil() {
+			return nil
+		}
+		template = template.Elem()
+	}
+	podTemplate, ok := template.Addr().Interface().(*coreV1.PodTemplateSpec)
+	if !ok {
+		return nil
+	}
+	return &podTemplate.Spec
+}
+
+// addMissingResources adds the resources of from that are missing in to.
+func addMissingResources(to, from coreV1.ResourceList) {
+	for name, quantity := range from {
+		if _, found := to[name]; !found {
+			to[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package defaults applies the defaults that the Kubernetes API server sets on objects when they are created, so that
+// checks see fields that are omitted from manifests with the values they will have in the cluster.
+//
+// The defaulters are those of the core, apps and batch API groups of k8s.io/kubernetes, registered with a
+// runtime.Scheme like the API server does. Admission applies the defaults of the admission plugins, which depend on
+// the other objects being linted.
+package defaults
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	appsV1 "k8s.io/kubernetes/pkg/apis/apps/v1"
+	batchV1 "k8s.io/kubernetes/pkg/apis/batch/v1"
+	coreV1 "k8s.io/kubernetes/pkg/apis/core/v1"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	for _, register := range []func(*runtime.Scheme) error{
+		coreV1.RegisterDefaults,
+		appsV1.RegisterDefaults,
+		batchV1.RegisterDefaults,
+	} {
+		if err := register(scheme); err != nil {
+			panic(fmt.Sprintf("registering defaulters: %v", err))
+		}
+	}
+}
+
+// Apply applies the defaults of the API server to the given object, in place. Objects of kinds that have no
+// defaulters are left unchanged.
+func Apply(obj runtime.Object) {
+	scheme.Default(obj)
+}
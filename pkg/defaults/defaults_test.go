@@ -0,0 +1,151 @@
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestApplyDeployment(t *testing.T) {
+	deployment := &appsV1.Deployment{
+		Spec: appsV1.DeploymentSpec{
+			Template: coreV1.PodTemplateSpec{
+				Spec: coreV1.PodSpec{
+					Volumes: []coreV1.Volume{{Name: "scratch"}},
+					Containers: []coreV1.Container{
+						{
+							Name:  "latest",
+							Image: "registry.example.com:5000/app",
+							Ports: []coreV1.ContainerPort{{ContainerPort: 8080}},
+							Resources: coreV1.ResourceRequirements{
+								Limits: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("1")},
+							},
+							LivenessProbe: &coreV1.Probe{ProbeHandler: coreV1.ProbeHandler{HTTPGet: &coreV1.HTTPGetAction{}}},
+						},
+						{Name: "tagged", Image: "app:1.0", ImagePullPolicy: coreV1.PullNever},
+						{Name: "pinned", Image: "app@sha256:0123456789abcdef"},
+					},
+				},
+			},
+		},
+	}
+	Apply(deployment)
+
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.Equal(t, appsV1.RollingUpdateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	require.NotNil(t, deployment.Spec.Strategy.RollingUpdate)
+	assert.Equal(t, "25%", deployment.Spec.Strategy.RollingUpdate.MaxSurge.String())
+	assert.Equal(t, int32(600), *deployment.Spec.ProgressDeadlineSeconds)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, coreV1.RestartPolicyAlways, podSpec.RestartPolicy)
+	assert.Equal(t, int64(30), *podSpec.TerminationGracePeriodSeconds)
+	assert.NotNil(t, podSpec.Volumes[0].EmptyDir)
+
+	latest := podSpec.Containers[0]
+	assert.Equal(t, coreV1.PullAlways, latest.ImagePullPolicy)
+	assert.Equal(t, coreV1.ProtocolTCP, latest.Ports[0].Protocol)
+	// Requests are only set from limits on pods, not on pod templates.
+	assert.Empty(t, latest.Resources.Requests)
+	assert.Equal(t, int32(3), latest.LivenessProbe.FailureThreshold)
+	assert.Equal(t, "/", latest.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, coreV1.PullNever, podSpec.Containers[1].ImagePullPolicy)
+	assert.Equal(t, coreV1.PullIfNotPresent, podSpec.Containers[2].ImagePullPolicy)
+}
+
+func TestApplyPod(t *testing.T) {
+	pod := &coreV1.Pod{
+		Spec: coreV1.PodSpec{
+			Containers: []coreV1.Container{{
+				Name: "app",
+				Resources: coreV1.ResourceRequirements{
+					Limits: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("1")},
+				},
+			}},
+		},
+	}
+	Apply(pod)
+
+	assert.Equal(t, "1", pod.Spec.Containers[0].Resources.Requests.Cpu().String())
+	assert.Equal(t, coreV1.DNSClusterFirst, pod.Spec.DNSPolicy)
+}
+
+func TestApplyService(t *testing.T) {
+	service := &coreV1.Service{
+		Spec: coreV1.ServiceSpec{
+			Type: coreV1.ServiceTypeNodePort,
+			Ports: []coreV1.ServicePort{
+				{Port: 80},
+				{Port: 443, TargetPort: intstr.FromString("https"), Protocol: coreV1.ProtocolUDP},
+			},
+		},
+	}
+	Apply(service)
+
+	assert.Equal(t, coreV1.ServiceAffinityNone, service.Spec.SessionAffinity)
+	assert.Equal(t, coreV1.ServiceExternalTrafficPolicyTypeCluster, service.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, coreV1.ProtocolTCP, service.Spec.Ports[0].Protocol)
+	assert.Equal(t, intstr.FromInt(80), service.Spec.Ports[0].TargetPort)
+	assert.Equal(t, coreV1.ProtocolUDP, service.Spec.Ports[1].Protocol)
+	assert.Equal(t, intstr.FromString("https"), service.Spec.Ports[1].TargetPort)
+}
+
+func TestAdmission(t *testing.T) {
+	limitRange := &coreV1.LimitRange{
+		ObjectMeta: metaV1.ObjectMeta{Name: "limits", Namespace: "apps"},
+		Spec: coreV1.LimitRangeSpec{
+			Limits: []coreV1.LimitRangeItem{{
+				Type: coreV1.LimitTypeContainer,
+				Max:  coreV1.ResourceList{coreV1.ResourceMemory: resource.MustParse("1Gi")},
+				Min:  coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m")},
+			}},
+		},
+	}
+	Apply(limitRange)
+	serviceAccount := &coreV1.ServiceAccount{
+		ObjectMeta:                   metaV1.ObjectMeta{Name: "app", Namespace: "apps"},
+		AutomountServiceAccountToken: pointers.Bool(false),
+	}
+	admission := NewAdmission(limitRange, serviceAccount)
+
+	newPod := func(namespace, serviceAccountName string) *coreV1.Pod {
+		return &coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: namespace},
+			Spec: coreV1.PodSpec{
+				ServiceAccountName: serviceAccountName,
+				Containers: []coreV1.Container{{
+					Name: "app",
+					Resources: coreV1.ResourceRequirements{
+						Requests: coreV1.ResourceList{coreV1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				}},
+			},
+		}
+	}
+
+	pod := newPod("apps", "app")
+	admission.Apply(pod)
+	resources := pod.Spec.Containers[0].Resources
+	assert.Equal(t, "1Gi", resources.Limits.Memory().String())
+	assert.Equal(t, "512Mi", resources.Requests.Memory().String())
+	assert.Equal(t, "100m", resources.Requests.Cpu().String())
+	assert.False(t, *pod.Spec.AutomountServiceAccountToken)
+
+	pod = newPod("other", "app")
+	admission.Apply(pod)
+	assert.Empty(t, pod.Spec.Containers[0].Resources.Limits)
+	assert.True(t, *pod.Spec.AutomountServiceAccountToken)
+
+	deployment := &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "apps"}}
+	deployment.Spec.Template.Spec = newPod("apps", "").Spec
+	admission.Apply(deployment)
+	assert.Equal(t, "1Gi", deployment.Spec.Template.Spec.Containers[0].Resources.Limits.Memory().String())
+	assert.True(t, *deployment.Spec.Template.Spec.AutomountServiceAccountToken)
+}
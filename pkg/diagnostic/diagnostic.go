@@ -8,7 +8,42 @@ import (
 type Diagnostic struct {
 	Message string
 
-	// TODO: add line number/col number
+	// Container is the name of the container the problem was found in, if the problem is specific to one.
+	Container string `json:",omitempty"`
+
+	// RelatedObjects are other objects involved in the problem, e.g. the Role referenced by a RoleBinding.
+	RelatedObjects []lintcontext.Object `json:",omitempty"`
+
+	// Fix, if set, is a suggested change to the object's source that resolves the problem.
+	Fix *Fix `json:",omitempty"`
+
+	// Line is the 1-based line of the object's source (ObjectMetadata.Raw) that the problem was found at,
+	// if the problem is specific to one.
+	Line int `json:",omitempty"`
+
+	// FieldPath is the path of the field of the object that the problem was found at, e.g.
+	// spec.template.spec.containers[0].image, if the problem is specific to one.
+	FieldPath string `json:",omitempty"`
+}
+
+// A Fix is a suggested change to the source of an object.
+type Fix struct {
+	Description  string
+	Replacements []Replacement
+}
+
+// A Replacement replaces a region of the object's source (ObjectMetadata.Raw) with new text.
+// Lines and columns are 1-based, and the end column is exclusive. An empty region inserts the text.
+type Replacement struct {
+	StartLine, StartColumn int
+	EndLine, EndColumn     int
+	InsertedText           string
+}
+
+// A Suppression records why a diagnostic was not reported.
+type Suppression struct {
+	// Justification is the value of the ignore annotation, if any.
+	Justification string
 }
 
 // WithContext puts a diagnostic in the context of which check emitted it,
@@ -18,4 +53,21 @@ type WithContext struct {
 	Check       string
 	Remediation string
 	Object      lintcontext.Object
+
+	// Suppression is set if the object was annotated to ignore the check.
+	Suppression *Suppression `json:",omitempty"`
+
+	// HelmSource is set if the object was rendered from a Helm chart, and locates the problem in the chart.
+	HelmSource *HelmSource `json:",omitempty"`
+}
+
+// A HelmSource locates a problem in the source of a Helm chart.
+type HelmSource struct {
+	// Template is the path of the template the object was rendered from, relative to the chart.
+	Template string
+	// StartLine and EndLine are the 1-based range of lines of the template the object was rendered from, if known.
+	StartLine int `json:",omitempty"`
+	EndLine   int `json:",omitempty"`
+	// ValuesKey is the key in values.yaml of the value that the field with the problem is substituted from, if any.
+	ValuesKey string `json:",omitempty"`
 }
@@ -1,6 +1,7 @@
 package customtypes
 
 import (
+	"golang.stackrox.io/kube-linter/internal/set"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -11,6 +12,10 @@ type PodSpec struct {
 	// only access the Containers array through utility functions in this package.
 	Containers struct{}
 	v1.PodSpec
+
+	// NativeSidecarNames holds the names of the init containers that are native sidecars, i.e. that have
+	// restartPolicy Always and keep running alongside the regular containers (Kubernetes 1.28+).
+	NativeSidecarNames set.StringSet
 }
 
 // AllContainers returns a list of all containers in the Pod, including Init, Regular, and Ephemeral
@@ -28,14 +33,42 @@ func (p *PodSpec) AllContainers() []v1.Container {
 	return allContainers
 }
 
-// NonInitContainers returns a list of all regular (non-init) containers in the Pod
+// NonInitContainers returns a list of all containers in the Pod that run alongside each other,
+// i.e. the native sidecars and the regular (non-init) containers
 func (p *PodSpec) NonInitContainers() []v1.Container {
+	return append(p.NativeSidecarContainers(), p.PodSpec.Containers...)
+}
+
+// RegularContainers returns a list of the regular containers in the Pod, excluding native sidecars
+func (p *PodSpec) RegularContainers() []v1.Container {
 	return p.PodSpec.Containers
 }
 
-// InitContainers returns a list of all init containers in the Pod
+// InitContainers returns a list of the init containers in the Pod that run to completion, excluding native sidecars
 func (p *PodSpec) InitContainers() []v1.Container {
-	return p.PodSpec.InitContainers
+	var initContainers []v1.Container
+	for _, container := range p.PodSpec.InitContainers {
+		if !p.IsNativeSidecar(container.Name) {
+			initContainers = append(initContainers, container)
+		}
+	}
+	return initContainers
+}
+
+// NativeSidecarContainers returns a list of the init containers in the Pod that are native sidecars
+func (p *PodSpec) NativeSidecarContainers() []v1.Container {
+	var sidecars []v1.Container
+	for _, container := range p.PodSpec.InitContainers {
+		if p.IsNativeSidecar(container.Name) {
+			sidecars = append(sidecars, container)
+		}
+	}
+	return sidecars
+}
+
+// IsNativeSidecar returns whether the init container with the given name is a native sidecar
+func (p *PodSpec) IsNativeSidecar(name string) bool {
+	return p.NativeSidecarNames.Contains(name)
 }
 
 // EphemeralContainers returns a list of all ephemeral containers in the Pod
@@ -0,0 +1,47 @@
+package extract
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+)
+
+// Annotations that Helm reads from the objects rendered from a chart.
+const (
+	HelmHookAnnotation             = "helm.sh/hook"
+	HelmHookWeightAnnotation       = "helm.sh/hook-weight"
+	HelmHookDeletePolicyAnnotation = "helm.sh/hook-delete-policy"
+	HelmResourcePolicyAnnotation   = "helm.sh/resource-policy"
+)
+
+// HelmHooks extracts the hooks, e.g. pre-install, that the given object is run as by Helm.
+// It returns nil if the object is not a hook.
+func HelmHooks(object k8sutil.Object) []string {
+	return splitHelmList(object.GetAnnotations()[HelmHookAnnotation])
+}
+
+// HelmHookWeight extracts the weight of the given hook, which orders the hooks of the same phase.
+// Like Helm, it treats a missing or invalid weight as zero.
+func HelmHookWeight(object k8sutil.Object) int {
+	weight, err := strconv.Atoi(strings.TrimSpace(object.GetAnnotations()[HelmHookWeightAnnotation]))
+	if err != nil {
+		return 0
+	}
+	return weight
+}
+
+// HelmHookDeletePolicies extracts the delete policies of the given hook, e.g. hook-succeeded.
+func HelmHookDeletePolicies(object k8sutil.Object) []string {
+	return splitHelmList(object.GetAnnotations()[HelmHookDeletePolicyAnnotation])
+}
+
+func splitHelmList(value string) []string {
+	var elems []string
+	for _, elem := range strings.Split(value, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+	return elems
+}
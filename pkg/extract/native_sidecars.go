@@ -0,0 +1,81 @@
+package extract
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+)
+
+const (
+	restartPolicyAlways = "Always"
+)
+
+var (
+	// nativeSidecars holds the names of the native sidecars of the objects that have any, i.e. of the init
+	// containers with restartPolicy Always. The restartPolicy of containers is not part of the Kubernetes API types
+	// kube-linter is built with, so it is read from the source of the objects when they are loaded.
+	nativeSidecars     = make(map[k8sutil.Object]set.StringSet)
+	nativeSidecarsLock sync.RWMutex
+)
+
+// RecordNativeSidecars reads the init containers with restartPolicy Always from the pod spec in the given source of
+// the object, so that PodSpec returns them as native sidecars. The source must be that of the object alone, not of a
+// list that contains it.
+func RecordNativeSidecars(obj k8sutil.Object, source []byte) {
+	names := nativeSidecarNamesFromSource(obj, source)
+	if names.IsEmpty() {
+		return
+	}
+	nativeSidecarsLock.Lock()
+	defer nativeSidecarsLock.Unlock()
+	nativeSidecars[obj] = names
+}
+
+// CopyNativeSidecars records the native sidecars of the given object for a copy of it.
+func CopyNativeSidecars(from, to k8sutil.Object) {
+	nativeSidecarsLock.Lock()
+	defer nativeSidecarsLock.Unlock()
+	if names, found := nativeSidecars[from]; found {
+		nativeSidecars[to] = names
+	}
+}
+
+func nativeSidecarNames(obj k8sutil.Object) set.StringSet {
+	nativeSidecarsLock.RLock()
+	defer nativeSidecarsLock.RUnlock()
+	return nativeSidecars[obj]
+}
+
+// nativeSidecarNamesFromSource returns the names of the init containers with restartPolicy Always in the pod spec of
+// the given source of the object.
+func nativeSidecarNamesFromSource(obj k8sutil.Object, source []byte) set.StringSet {
+	if !bytes.Contains(source, []byte("restartPolicy")) {
+		return nil
+	}
+	podSpecPath, found := PodSpecPath(obj)
+	if !found {
+		return nil
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return nil
+	}
+	for _, field := range strings.Split(podSpecPath, ".") {
+		fields, _ := doc.(map[string]interface{})
+		doc = fields[field]
+	}
+	podSpec, _ := doc.(map[string]interface{})
+	initContainers, _ := podSpec["initContainers"].([]interface{})
+	names := set.NewStringSet()
+	for _, initContainer := range initContainers {
+		fields, _ := initContainer.(map[string]interface{})
+		if name, ok := fields["name"].(string); ok && fields["restartPolicy"] == restartPolicyAlways {
+			names.Add(name)
+		}
+	}
+	return names
+}
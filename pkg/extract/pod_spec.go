@@ -45,13 +45,28 @@ func PodTemplateSpec(obj k8sutil.Object) (coreV1.PodTemplateSpec, bool) {
 	}
 }
 
-// PodSpec extracts a pod spec from the given object, if available.
+// PodSpec extracts a pod spec from the given object, if available, with the native sidecars recorded for the object.
 func PodSpec(obj k8sutil.Object) (customtypes.PodSpec, bool) {
 	podTemplateSpec, found := PodTemplateSpec(obj)
 	if !found {
 		return customtypes.PodSpec{}, false
 	}
-	return customtypes.PodSpec{PodSpec: podTemplateSpec.Spec}, true
+	return customtypes.PodSpec{PodSpec: podTemplateSpec.Spec, NativeSidecarNames: nativeSidecarNames(obj)}, true
+}
+
+// PodSpecPath returns the path of the pod spec in the given object, e.g. spec.template.spec, if it has one.
+func PodSpecPath(obj k8sutil.Object) (string, bool) {
+	if _, found := PodTemplateSpec(obj); !found {
+		return "", false
+	}
+	switch obj.(type) {
+	case *coreV1.Pod:
+		return "spec", true
+	case *batchV1Beta1.CronJob, *batchV1.CronJob:
+		return "spec.jobTemplate.spec.template.spec", true
+	default:
+		return "spec.template.spec", true
+	}
 }
 
 // Selector extracts a selector from the given object, if available.
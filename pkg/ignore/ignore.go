@@ -1,7 +1,7 @@
 package ignore
 
 import (
-	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"strings"
 )
 
 const (
@@ -10,18 +10,67 @@ const (
 
 	// AllAnnotationKey is used to ignore all checks for a given object.
 	AllAnnotationKey = "kube-linter.io/ignore-all"
+
+	// ContainersValuePrefix starts an annotation value that restricts the ignore to the listed containers,
+	// e.g. "containers=sidecar,istio-proxy". Anything after the list is the justification.
+	ContainersValuePrefix = "containers="
 )
 
-// ObjectForCheck returns whether to ignore the given object for the passed check name.
-func ObjectForCheck(annotations map[string]string, checkName string) bool {
-	for k := range annotations {
-		if k == AllAnnotationKey {
-			return true
-		}
-		key := k
-		if stringutils.ConsumePrefix(&key, AnnotationKeyPrefix) && key == checkName {
+// A Suppression is parsed from an annotation that ignores a check.
+type Suppression struct {
+	// Justification is the value of the annotation, without any list of containers.
+	Justification string
+	// Containers, if not empty, restricts the suppression to problems found in the named containers.
+	Containers []string
+}
+
+// AppliesTo returns whether the suppression covers a problem found in the given container.
+// An empty container name stands for a problem that is not specific to a container.
+func (s Suppression) AppliesTo(container string) bool {
+	if len(s.Containers) == 0 {
+		return true
+	}
+	for _, c := range s.Containers {
+		if c == container {
 			return true
 		}
 	}
 	return false
 }
+
+// ForCheck returns the suppression of the passed check name, and whether there is one.
+// The annotation sets are searched in order, so they should go from the most to the least specific
+// (e.g. object, pod template, namespace), and the first set that ignores the check wins.
+func ForCheck(checkName string, annotationSets ...map[string]string) (Suppression, bool) {
+	for _, annotations := range annotationSets {
+		if value, ok := annotations[AnnotationKeyPrefix+checkName]; ok {
+			return parseSuppression(value), true
+		}
+		if value, ok := annotations[AllAnnotationKey]; ok {
+			return parseSuppression(value), true
+		}
+	}
+	return Suppression{}, false
+}
+
+// ObjectForCheck returns whether to ignore the given object for the passed check name. An object is not ignored
+// by annotations that only ignore the check for some of its containers.
+func ObjectForCheck(annotations map[string]string, checkName string) bool {
+	suppression, found := ForCheck(checkName, annotations)
+	return found && suppression.AppliesTo("")
+}
+
+func parseSuppression(value string) Suppression {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, ContainersValuePrefix) {
+		return Suppression{Justification: value}
+	}
+	list, justification, _ := strings.Cut(strings.TrimPrefix(trimmed, ContainersValuePrefix), " ")
+	var containers []string
+	for _, container := range strings.Split(list, ",") {
+		if container = strings.TrimSpace(container); container != "" {
+			containers = append(containers, container)
+		}
+	}
+	return Suppression{Justification: strings.TrimSpace(justification), Containers: containers}
+}
@@ -75,3 +75,83 @@ func TestObjectForCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestForCheck(t *testing.T) {
+	suppression, ignored := ForCheck("some-check", map[string]string{
+		"kube-linter.io/ignore-all":              "Too much of a mess",
+		"ignore-check.kube-linter.io/some-check": "Not applicable",
+	})
+	assert.True(t, ignored)
+	assert.Equal(t, Suppression{Justification: "Not applicable"}, suppression)
+
+	suppression, ignored = ForCheck("some-check", map[string]string{
+		"kube-linter.io/ignore-all": "Too much of a mess",
+	})
+	assert.True(t, ignored)
+	assert.Equal(t, Suppression{Justification: "Too much of a mess"}, suppression)
+
+	_, ignored = ForCheck("some-check", nil)
+	assert.False(t, ignored)
+
+	// More specific annotation sets win.
+	suppression, ignored = ForCheck("some-check",
+		nil,
+		map[string]string{"ignore-check.kube-linter.io/some-check": "From the pod template"},
+		map[string]string{"kube-linter.io/ignore-all": "From the namespace"},
+	)
+	assert.True(t, ignored)
+	assert.Equal(t, "From the pod template", suppression.Justification)
+
+	suppression, ignored = ForCheck("other-check",
+		map[string]string{"ignore-check.kube-linter.io/some-check": "Not applicable"},
+		map[string]string{"kube-linter.io/ignore-all": "From the namespace"},
+	)
+	assert.True(t, ignored)
+	assert.Equal(t, "From the namespace", suppression.Justification)
+}
+
+func TestContainerSuppressions(t *testing.T) {
+	for _, testCase := range []struct {
+		value                 string
+		expectedSuppression   Suppression
+		appliesToContainers   []string
+		notAppliesToContainer string
+	}{
+		{
+			value:               "Not applicable",
+			expectedSuppression: Suppression{Justification: "Not applicable"},
+			appliesToContainers: []string{"", "app", "sidecar"},
+		},
+		{
+			value:                 "containers=sidecar,istio-proxy",
+			expectedSuppression:   Suppression{Containers: []string{"sidecar", "istio-proxy"}},
+			appliesToContainers:   []string{"sidecar", "istio-proxy"},
+			notAppliesToContainer: "app",
+		},
+		{
+			value: "containers=istio-proxy Injected by the service mesh",
+			expectedSuppression: Suppression{
+				Justification: "Injected by the service mesh",
+				Containers:    []string{"istio-proxy"},
+			},
+			appliesToContainers:   []string{"istio-proxy"},
+			notAppliesToContainer: "app",
+		},
+	} {
+		c := testCase
+		t.Run(c.value, func(t *testing.T) {
+			annotations := map[string]string{"ignore-check.kube-linter.io/some-check": c.value}
+			suppression, ignored := ForCheck("some-check", annotations)
+			assert.True(t, ignored)
+			assert.Equal(t, c.expectedSuppression, suppression)
+			for _, container := range c.appliesToContainers {
+				assert.True(t, suppression.AppliesTo(container), container)
+			}
+			if c.notAppliesToContainer != "" {
+				assert.False(t, suppression.AppliesTo(c.notAppliesToContainer))
+				assert.False(t, suppression.AppliesTo(""))
+			}
+			assert.Equal(t, suppression.AppliesTo(""), ObjectForCheck(annotations, "some-check"))
+		})
+	}
+}
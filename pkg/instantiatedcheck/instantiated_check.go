@@ -34,6 +34,9 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 	if !validCheckNameRegex.MatchString(c.Name) {
 		validationErrs.AddStringf("invalid name %s, must match regex %s", c.Name, validCheckNameRegex.String())
 	}
+	if c.Severity != "" && !isValidSeverity(c.Severity) {
+		validationErrs.AddStringf("invalid severity %q, must be one of %v", c.Severity, config.Severities)
+	}
 	template, found := templates.Get(c.Template)
 	if !found {
 		validationErrs.AddStringf("template %q not found", c.Template)
@@ -68,3 +71,12 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 	i.Func = checkFunc
 	return i, nil
 }
+
+func isValidSeverity(severity config.Severity) bool {
+	for _, valid := range config.Severities {
+		if severity == valid {
+			return true
+		}
+	}
+	return false
+}
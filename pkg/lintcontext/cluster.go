@@ -0,0 +1,26 @@
+package lintcontext
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+// A ClusterContext is a LintContext that describes the cluster the objects are deployed to, for checks that
+// simulate how the cluster handles them.
+type ClusterContext interface {
+	LintContext
+	// NodePools returns the node pools of the cluster, or nil if they aren't known.
+	NodePools() []config.NodePool
+}
+
+// NodePools returns the node pools declared in the options the context was created with.
+func (l *lintContextImpl) NodePools() []config.NodePool {
+	return l.nodePools
+}
+
+// NodePools returns the node pools of the wrapped context.
+func (t *transitionContextImpl) NodePools() []config.NodePool {
+	if clusterCtx, ok := t.LintContext.(ClusterContext); ok {
+		return clusterCtx.NodePools()
+	}
+	return nil
+}
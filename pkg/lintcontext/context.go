@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 
 	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -12,13 +15,44 @@ import (
 // ObjectMetadata is metadata about an object.
 type ObjectMetadata struct {
 	FilePath string
-	Raw      []byte `json:"-"`
+	// LineNumber is the 1-based line of FilePath at which the object's document starts, or 0 if unknown.
+	LineNumber int    `json:",omitempty"`
+	Raw        []byte `json:"-"`
+	// TemplateLineNumber is the 1-based line of FilePath at which the template that the object was rendered from
+	// starts, for objects rendered by ytt. Their Raw is the rendered document, so LineNumber is left unknown.
+	TemplateLineNumber int `json:",omitempty"`
+	// HelmChart is set if the object was rendered from a Helm chart.
+	HelmChart *HelmChartMetadata `json:",omitempty"`
+	// HelmTemplate is set if the object was rendered from a Helm chart, and its template is known.
+	HelmTemplate *HelmTemplateMetadata `json:",omitempty"`
+}
+
+// HelmChartMetadata describes the Helm chart an object was rendered from.
+type HelmChartMetadata struct {
+	// Path is the path of the chart directory or archive.
+	Path string
+	Name string
+	// Annotations are the annotations declared in the chart's Chart.yaml.
+	Annotations map[string]string `json:",omitempty"`
+	// Release is set if the chart was rendered as a release that is declared in another file, and names its lint
+	// context, i.e. <helmfile>#<release>@<environment> for helmfiles and <file>#helm_release.<name> for Terraform.
+	Release string `json:",omitempty"`
 }
 
 // An Object references an object that is loaded from a YAML file.
 type Object struct {
 	Metadata  ObjectMetadata
 	K8sObject k8sutil.Object `json:"-"`
+	// Original is the object as written in its source, if K8sObject had the defaults of the API server applied.
+	Original k8sutil.Object `json:"-"`
+}
+
+// Source returns the object as written in its source, without the defaults of the API server applied.
+func (o *Object) Source() k8sutil.Object {
+	if o.Original != nil {
+		return o.Original
+	}
+	return o.K8sObject
 }
 
 // K8sObjectInfo contains identifying information about k8s object.
@@ -73,11 +107,36 @@ type LintContext interface {
 	InvalidObjects() []InvalidObject
 }
 
+// A Note is information about how objects were loaded that doesn't make them invalid, e.g. that some of their
+// fields could not be evaluated.
+type Note struct {
+	Metadata ObjectMetadata
+	Message  string
+	// Object is the object the note is about, if it was loaded.
+	Object k8sutil.Object
+}
+
+// A NotesContext is a LintContext with notes about how its objects were loaded.
+type NotesContext interface {
+	LintContext
+	Notes() []Note
+}
+
 type lintContextImpl struct {
 	objects        []Object
 	invalidObjects []InvalidObject
+	notes          []Note
 
 	customDecoder runtime.Decoder
+	applyDefaults bool
+	nodePools     []config.NodePool
+	helm          config.HelmConfig
+	helmRelease   chartutil.ReleaseOptions
+
+	// helmLookupObjects are the objects of the Helm lookup directory, loaded when a chart is first rendered.
+	helmLookupObjects []unstructured.Unstructured
+	// terraformModule holds the Terraform files of the directory of the context, loaded with the first of them.
+	terraformModule *terraformModule
 }
 
 // Objects returns the (valid) objects loaded from this LintContext.
@@ -100,9 +159,23 @@ func (l *lintContextImpl) addInvalidObjects(objs ...InvalidObject) {
 	l.invalidObjects = append(l.invalidObjects, objs...)
 }
 
+// Notes returns the notes about how the objects of this LintContext were loaded.
+func (l *lintContextImpl) Notes() []Note {
+	return l.notes
+}
+
+// addNotes adds notes to this LintContext
+func (l *lintContextImpl) addNotes(notes ...Note) {
+	l.notes = append(l.notes, notes...)
+}
+
 // new returns a ready-to-use, empty, lintContextImpl.
 func newCtx(options Options) *lintContextImpl {
 	return &lintContextImpl{
 		customDecoder: options.CustomDecoder,
+		applyDefaults: options.ApplyDefaults,
+		nodePools:     options.NodePools,
+		helm:          options.Helm,
+		helmRelease:   chartutil.ReleaseOptions{Name: "test-release", Namespace: "default"},
 	}
 }
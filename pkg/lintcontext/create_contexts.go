@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/config"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -22,6 +23,27 @@ type Options struct {
 	// CustomDecoder allows users to supply a non-default decoder to parse k8s objects. This can be used
 	// to allow the linter to create contexts for k8s custom resources
 	CustomDecoder runtime.Decoder
+	// ApplyDefaults, if set, applies the defaults that the API server sets on objects when they are created,
+	// including the default resources of the LimitRanges in the context, before the objects are linted.
+	ApplyDefaults bool
+	// NodePools describes the nodes of the cluster the objects are deployed to, for checks that simulate the
+	// scheduling of pods.
+	NodePools []config.NodePool
+	// Helm configures how Helm charts are rendered: the capabilities of the cluster, the objects that templates
+	// can look up and the post-renderer.
+	Helm config.HelmConfig
+	// HelmfileEnvironment is the environment that the releases of helmfiles are rendered for.
+	// It defaults to DefaultHelmfileEnvironment.
+	HelmfileEnvironment string
+	// Markdown, if set, loads the objects of the fenced YAML code blocks of Markdown files, except for the blocks
+	// whose info string has the MarkdownSkipMarker.
+	Markdown bool
+	// Terraform, if set, loads the objects that the kubernetes_manifest resources and the typed resources of the
+	// kubernetes provider of Terraform files declare, and renders the local charts of their helm_release resources.
+	Terraform bool
+	// Ytt configures which directories are ytt configurations, which are rendered instead of their YAML files being
+	// loaded as they are.
+	Ytt config.YttConfig
 }
 
 // CreateContexts creates a context. Each context contains a set of files that should be linted
@@ -67,7 +89,35 @@ func CreateContextsWithOptions(options Options, filesOrDirs ...string) ([]LintCo
 					return nil
 				}
 
+				if isHelmfile(currentPath) {
+					for name, ctx := range createHelmfileContexts(options, currentPath) {
+						contextsByDir[name] = ctx
+					}
+					return nil
+				}
+
 				dirName := filepath.Dir(currentPath)
+				if options.Terraform && isTerraformFile(currentPath) {
+					ctx := contextsByDir[dirName]
+					if ctx == nil {
+						ctx = newCtx(options)
+						contextsByDir[dirName] = ctx
+					}
+					for name, releaseCtx := range ctx.loadObjectsFromTerraformFile(options, currentPath) {
+						contextsByDir[name] = releaseCtx
+					}
+					return nil
+				}
+
+				if options.Markdown && isMarkdownFile(currentPath) {
+					ctx := contextsByDir[dirName]
+					if ctx == nil {
+						ctx = newCtx(options)
+						contextsByDir[dirName] = ctx
+					}
+					return ctx.loadObjectsFromMarkdownFile(currentPath, info)
+				}
+
 				// Load a file only if it ends in .yaml, OR it was explicitly passed by the user.
 				if knownYAMLExtensions.Contains(strings.ToLower(filepath.Ext(currentPath))) || fileOrDir == currentPath {
 					ctx := contextsByDir[dirName]
@@ -81,6 +131,12 @@ func CreateContextsWithOptions(options Options, filesOrDirs ...string) ([]LintCo
 				}
 				return nil
 			}
+			if isYttDirectory(options.Ytt, currentPath) {
+				ctx := newCtx(options)
+				contextsByDir[currentPath] = ctx
+				ctx.loadObjectsFromYttDirectory(currentPath, options.Ytt.DataValuesFiles)
+				return filepath.SkipDir
+			}
 			if isHelm, _ := chartutil.IsChartDir(currentPath); isHelm {
 				// Path has already been loaded, possibly through another argument. Skip.
 				if _, alreadyExists := contextsByDir[currentPath]; alreadyExists {
@@ -104,7 +160,11 @@ func CreateContextsWithOptions(options Options, filesOrDirs ...string) ([]LintCo
 	sort.Strings(dirs)
 	var contexts []LintContext
 	for _, dir := range dirs {
-		contexts = append(contexts, contextsByDir[dir])
+		ctx := contextsByDir[dir]
+		if ctx.applyDefaults {
+			ctx.applyObjectDefaults()
+		}
+		contexts = append(contexts, ctx)
 	}
 	return contexts, nil
 }
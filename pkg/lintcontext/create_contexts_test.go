@@ -105,6 +105,9 @@ func checkObjectPaths(t *testing.T, objects []Object, expectedPrefix string) {
 	actualPaths := make([]string, 0, len(objects))
 	for _, obj := range objects {
 		actualPaths = append(actualPaths, obj.Metadata.FilePath)
+		if assert.NotNil(t, obj.Metadata.HelmChart, "object %s has no chart metadata", obj.Metadata.FilePath) {
+			assert.Equal(t, "mychart", obj.Metadata.HelmChart.Name)
+		}
 	}
 	expectedPaths := []string{
 		path.Join(expectedPrefix, "templates/deployment.yaml"),
@@ -0,0 +1,28 @@
+package lintcontext
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/defaults"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+)
+
+// applyObjectDefaults replaces the objects of the context with copies that have the defaults applied that the
+// API server would set on creation, keeping the objects as written in Object.Original.
+func (l *lintContextImpl) applyObjectDefaults() {
+	defaulted := make([]k8sutil.Object, 0, len(l.objects))
+	for _, obj := range l.objects {
+		copied, ok := obj.K8sObject.DeepCopyObject().(k8sutil.Object)
+		if !ok {
+			copied = obj.K8sObject
+		}
+		extract.CopyNativeSidecars(obj.K8sObject, copied)
+		defaults.Apply(copied)
+		defaulted = append(defaulted, copied)
+	}
+	admission := defaults.NewAdmission(defaulted...)
+	for i := range l.objects {
+		admission.Apply(defaulted[i])
+		l.objects[i].Original = l.objects[i].K8sObject
+		l.objects[i].K8sObject = defaulted[i]
+	}
+}
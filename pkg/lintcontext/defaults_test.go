@@ -0,0 +1,31 @@
+package lintcontext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyObjectDefaults(t *testing.T) {
+	service := &coreV1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app"},
+		Spec:       coreV1.ServiceSpec{Ports: []coreV1.ServicePort{{Port: 80}}},
+	}
+	ctx := newCtx(Options{ApplyDefaults: true})
+	ctx.addObjects(Object{Metadata: ObjectMetadata{FilePath: "service.yaml", LineNumber: 1}, K8sObject: service})
+	ctx.applyObjectDefaults()
+
+	objects := ctx.Objects()
+	require.Len(t, objects, 1)
+	defaulted, ok := objects[0].K8sObject.(*coreV1.Service)
+	require.True(t, ok)
+	assert.Equal(t, coreV1.ServiceTypeClusterIP, defaulted.Spec.Type)
+	assert.Equal(t, int32(80), defaulted.Spec.Ports[0].TargetPort.IntVal)
+
+	assert.Same(t, service, objects[0].Source())
+	assert.Empty(t, service.Spec.Type)
+	assert.Equal(t, 1, objects[0].Metadata.LineNumber)
+}
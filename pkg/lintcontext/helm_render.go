@@ -0,0 +1,411 @@
+package lintcontext
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	y "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/clusterprofiles"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/postrender"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// helmSourcePrefix starts the comment that Helm adds to each template it passes to post-renderers.
+	helmSourcePrefix = "# Source: "
+
+	yamlSeparator = "---"
+)
+
+// helmCapabilities returns the capabilities of the cluster that charts are rendered for.
+func (l *lintContextImpl) helmCapabilities() (*chartutil.Capabilities, error) {
+	capabilities := *chartutil.DefaultCapabilities
+	if l.helm.ClusterProfile != "" {
+		profile, err := clusterprofiles.Get(l.helm.ClusterProfile)
+		if err != nil {
+			return nil, err
+		}
+		kubeVersion, err := chartutil.ParseKubeVersion(profile.KubeVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid Kubernetes version %q of cluster profile %s", profile.KubeVersion, profile.Name)
+		}
+		capabilities.KubeVersion = *kubeVersion
+		capabilities.APIVersions = profile.VersionSet()
+	}
+	if l.helm.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(l.helm.KubeVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid Kubernetes version %q", l.helm.KubeVersion)
+		}
+		capabilities.KubeVersion = *kubeVersion
+	}
+	// Like helm does, the API versions that are set are added to the default ones, or to those of the profile.
+	capabilities.APIVersions = append(append(chartutil.VersionSet(nil), capabilities.APIVersions...), l.helm.APIVersions...)
+	return &capabilities, nil
+}
+
+// helmRenderFunc renders the templates of a chart with the given values.
+type helmRenderFunc func(chrt *chart.Chart, values chartutil.Values) (map[string]string, error)
+
+// helmRenderer returns the function to render charts with, and a function that releases it. If a lookup directory
+// is configured, the lookup function of templates queries a stub of the API server that serves its objects.
+// Helm only backs lookup by a cluster outside of its lint mode, so charts are made lenient like in lint mode first.
+func (l *lintContextImpl) helmRenderer() (helmRenderFunc, func(), error) {
+	if l.helm.LookupDirectory == "" {
+		return engine.Engine{LintMode: true}.Render, func() {}, nil
+	}
+	if l.helmLookupObjects == nil {
+		objects, err := loadLookupObjects(l.helm.LookupDirectory)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "loading the objects for lookup from %s", l.helm.LookupDirectory)
+		}
+		l.helmLookupObjects = objects
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "starting the API server stub for lookup")
+	}
+	server := &http.Server{Handler: &lookupServer{objects: l.helmLookupObjects}}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	config := &rest.Config{Host: "http://" + listener.Addr().String()}
+	render := func(chrt *chart.Chart, values chartutil.Values) (map[string]string, error) {
+		return engine.RenderWithClient(lenientChart(chrt), values, config)
+	}
+	return render, func() {
+		_ = server.Close()
+	}, nil
+}
+
+// lenientChart returns a copy of the given chart, including its dependencies, whose templates don't fail on missing
+// required values and on calls to fail, like in the lint mode of Helm: required returns the value as it is, and fail
+// returns an empty string. Templates that can't be parsed are kept as they are, for the engine to report.
+func lenientChart(chrt *chart.Chart) *chart.Chart {
+	copied := *chrt
+	copied.Templates = make([]*chart.File, 0, len(chrt.Templates))
+	for _, template := range chrt.Templates {
+		copied.Templates = append(copied.Templates, &chart.File{Name: template.Name, Data: lenientTemplate(template.Name, template.Data)})
+	}
+	dependencies := make([]*chart.Chart, 0, len(chrt.Dependencies()))
+	for _, dependency := range chrt.Dependencies() {
+		dependencies = append(dependencies, lenientChart(dependency))
+	}
+	copied.SetDependencies(dependencies...)
+	return &copied
+}
+
+// lenientFunctions maps the functions that fail rendering outside of lint mode to the replacement of their name.
+// Since the arguments of required are a message, which isn't empty, and the value, "and" returns the value;
+// "and" returns its first argument if it is empty, so it returns an empty string instead of calling fail.
+var lenientFunctions = map[string]string{
+	"required": "and",
+	"fail":     `and ""`,
+}
+
+// lenientTemplate replaces the calls of the functions in lenientFunctions in the given template. Only the names of
+// the functions are replaced, so that the template is otherwise unchanged, including its line numbers.
+func lenientTemplate(name string, data []byte) []byte {
+	treeSet := make(map[string]*parse.Tree)
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(string(data), "", "", treeSet); err != nil {
+		return data
+	}
+	var calls []*parse.IdentifierNode
+	var visit func(node parse.Node)
+	visit = func(node parse.Node) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node == nil {
+				return
+			}
+			for _, child := range node.Nodes {
+				visit(child)
+			}
+		case *parse.ActionNode:
+			visit(node.Pipe)
+		case *parse.IfNode:
+			visit(&node.BranchNode)
+		case *parse.RangeNode:
+			visit(&node.BranchNode)
+		case *parse.WithNode:
+			visit(&node.BranchNode)
+		case *parse.BranchNode:
+			visit(node.Pipe)
+			visit(node.List)
+			visit(node.ElseList)
+		case *parse.TemplateNode:
+			visit(node.Pipe)
+		case *parse.ChainNode:
+			visit(node.Node)
+		case *parse.PipeNode:
+			if node == nil {
+				return
+			}
+			for _, cmd := range node.Cmds {
+				if identifier, ok := cmd.Args[0].(*parse.IdentifierNode); ok && lenientFunctions[identifier.Ident] != "" {
+					calls = append(calls, identifier)
+				}
+				for _, arg := range cmd.Args {
+					visit(arg)
+				}
+			}
+		}
+	}
+	for _, t := range treeSet {
+		visit(t.Root)
+	}
+	if len(calls) == 0 {
+		return data
+	}
+
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Pos < calls[j].Pos
+	})
+	var lenient bytes.Buffer
+	last := 0
+	for _, call := range calls {
+		lenient.Write(data[last:call.Pos])
+		lenient.WriteString(lenientFunctions[call.Ident])
+		last = int(call.Pos) + len(call.Ident)
+	}
+	lenient.Write(data[last:])
+	return lenient.Bytes()
+}
+
+// loadLookupObjects loads the objects of the YAML files in the given directory, as the API server would return them.
+func loadLookupObjects(dir string) ([]unstructured.Unstructured, error) {
+	objects := make([]unstructured.Unstructured, 0)
+	err := filepath.Walk(dir, func(currentPath string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if info.IsDir() || !knownYAMLExtensions.Contains(strings.ToLower(filepath.Ext(currentPath))) {
+			return nil
+		}
+		contents, err := os.ReadFile(filepath.Clean(currentPath))
+		if err != nil {
+			return err
+		}
+		reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(contents)))
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", currentPath)
+			}
+			var obj unstructured.Unstructured
+			if err := y.Unmarshal(doc, &obj.Object); err != nil {
+				return errors.Wrapf(err, "parsing %s", currentPath)
+			}
+			if obj.GetKind() == "" {
+				continue
+			}
+			if !obj.IsList() {
+				objects = append(objects, obj)
+				continue
+			}
+			if err := obj.EachListItem(func(item runtime.Object) error {
+				objects = append(objects, *item.(*unstructured.Unstructured))
+				return nil
+			}); err != nil {
+				return errors.Wrapf(err, "parsing %s", currentPath)
+			}
+		}
+		return nil
+	})
+	return objects, err
+}
+
+// lookupServer is a stub of the API server that serves the discovery and the get and list requests of the lookup
+// function of templates. Objects that don't have a namespace are returned for any namespace.
+type lookupServer struct {
+	objects []unstructured.Unstructured
+}
+
+func (s *lookupServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var groupVersion schema.GroupVersion
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		groupVersion = schema.GroupVersion{Version: segments[1]}
+		segments = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		groupVersion = schema.GroupVersion{Group: segments[1], Version: segments[2]}
+		segments = segments[3:]
+	default:
+		writeLookupNotFound(w, r.URL.Path)
+		return
+	}
+	if len(segments) == 0 {
+		writeLookupResponse(w, s.resourceList(groupVersion))
+		return
+	}
+
+	var namespace string
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		namespace, segments = segments[1], segments[2:]
+	}
+	resource, name := segments[0], ""
+	if len(segments) > 1 {
+		name = segments[1]
+	}
+	var items []interface{}
+	for i := range s.objects {
+		obj := &s.objects[i]
+		gvk := obj.GroupVersionKind()
+		if gvk.GroupVersion() != groupVersion || lookupResource(gvk) != resource {
+			continue
+		}
+		if namespace != "" && obj.GetNamespace() != "" && obj.GetNamespace() != namespace {
+			continue
+		}
+		if name != "" && obj.GetName() != name {
+			continue
+		}
+		items = append(items, obj.Object)
+	}
+
+	if name == "" {
+		writeLookupResponse(w, map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": append([]interface{}{}, items...)})
+		return
+	}
+	if len(items) == 0 {
+		writeLookupNotFound(w, r.URL.Path)
+		return
+	}
+	writeLookupResponse(w, items[0])
+}
+
+// resourceList returns the discovery information of the resources of the given group version.
+func (s *lookupServer) resourceList(groupVersion schema.GroupVersion) *metaV1.APIResourceList {
+	list := &metaV1.APIResourceList{
+		TypeMeta:     metaV1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: groupVersion.String(),
+	}
+	resourceIndexes := make(map[string]int)
+	for i := range s.objects {
+		gvk := s.objects[i].GroupVersionKind()
+		if gvk.GroupVersion() != groupVersion {
+			continue
+		}
+		idx, found := resourceIndexes[gvk.Kind]
+		if !found {
+			idx = len(list.APIResources)
+			resourceIndexes[gvk.Kind] = idx
+			list.APIResources = append(list.APIResources, metaV1.APIResource{
+				Name:  lookupResource(gvk),
+				Kind:  gvk.Kind,
+				Verbs: metaV1.Verbs{"get", "list"},
+			})
+		}
+		list.APIResources[idx].Namespaced = list.APIResources[idx].Namespaced || s.objects[i].GetNamespace() != ""
+	}
+	return list
+}
+
+func lookupResource(gvk schema.GroupVersionKind) string {
+	plural, _ := meta.UnsafeGuessKindToResource(gvk)
+	return plural.Resource
+}
+
+func writeLookupResponse(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeLookupNotFound(w http.ResponseWriter, path string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(&metaV1.Status{
+		TypeMeta: metaV1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metaV1.StatusFailure,
+		Message:  fmt.Sprintf("%s not found", path),
+		Reason:   metaV1.StatusReasonNotFound,
+		Code:     http.StatusNotFound,
+	})
+}
+
+// postRender pipes the rendered templates through the configured post-renderer, if any. Like when helm installs
+// a chart, each template is preceded by a comment with its path, which is used to attribute the documents of the
+// output back to the templates. Documents that lost the comment are attributed to the chart itself.
+func (l *lintContextImpl) postRender(rendered map[string]string) (map[string]string, error) {
+	if l.helm.PostRenderer == "" {
+		return rendered, nil
+	}
+	renderer, err := postrender.NewExec(l.helm.PostRenderer, l.helm.PostRendererArgs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting up the post-renderer")
+	}
+
+	paths := make([]string, 0, len(rendered))
+	postRendered := make(map[string]string)
+	for path, contents := range rendered {
+		if strings.HasSuffix(path, "/"+chartutil.NotesName) {
+			postRendered[path] = contents
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	manifests := &bytes.Buffer{}
+	for _, path := range paths {
+		if strings.TrimSpace(rendered[path]) != "" {
+			fmt.Fprintf(manifests, "%s\n%s%s\n%s\n", yamlSeparator, helmSourcePrefix, path, rendered[path])
+		}
+	}
+
+	output, err := renderer.Run(manifests)
+	if err != nil {
+		return nil, errors.Wrap(err, "running the post-renderer")
+	}
+	reader := yaml.NewYAMLReader(bufio.NewReader(output))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading the output of the post-renderer")
+		}
+		// The reader keeps the separator that starts a document.
+		content := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(doc)), yamlSeparator))
+		if content == "" {
+			continue
+		}
+		var path string
+		for _, line := range strings.Split(content, "\n") {
+			if strings.HasPrefix(line, helmSourcePrefix) {
+				path = strings.TrimSpace(strings.TrimPrefix(line, helmSourcePrefix))
+				break
+			}
+		}
+		postRendered[path] += yamlSeparator + "\n" + content + "\n"
+	}
+	return postRendered, nil
+}
@@ -0,0 +1,112 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const (
+	configMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  kubeVersion: {{ .Capabilities.KubeVersion.Version | quote }}
+  {{- if .Capabilities.APIVersions.Has "monitoring.coreos.com/v1" }}
+  monitoring: "true"
+  {{- end }}
+  password: {{ dig "data" "password" "none" (lookup "v1" "Secret" "default" "db") | quote }}
+  owner: "{{ required "owner is required" .Values.owner }}"
+`
+
+	lookupSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: default
+data:
+  password: c2VjcmV0
+`
+)
+
+func renderTestChart(t *testing.T, helm config.HelmConfig) string {
+	chrt := &chart.Chart{
+		Metadata:  &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "app", Version: "0.1.0"},
+		Templates: []*chart.File{{Name: "templates/configmap.yaml", Data: []byte(configMapTemplate)}},
+	}
+	rendered, err := newCtx(Options{Helm: helm}).renderValues(chrt, map[string]interface{}{})
+	require.NoError(t, err)
+	return rendered["app/templates/configmap.yaml"]
+}
+
+func TestRenderWithDefaultCapabilities(t *testing.T) {
+	rendered := renderTestChart(t, config.HelmConfig{})
+	assert.NotContains(t, rendered, "monitoring")
+	assert.Contains(t, rendered, `password: "none"`)
+}
+
+func TestRenderWithCapabilities(t *testing.T) {
+	rendered := renderTestChart(t, config.HelmConfig{
+		KubeVersion: "v1.21.3",
+		APIVersions: []string{"monitoring.coreos.com/v1"},
+	})
+	assert.Contains(t, rendered, `kubeVersion: "v1.21.3"`)
+	assert.Contains(t, rendered, `monitoring: "true"`)
+}
+
+func TestRenderWithClusterProfile(t *testing.T) {
+	rendered := renderTestChart(t, config.HelmConfig{ClusterProfile: "openshift-4.12"})
+	assert.Contains(t, rendered, `kubeVersion: "v1.25.0"`)
+	assert.Contains(t, rendered, `monitoring: "true"`)
+
+	rendered = renderTestChart(t, config.HelmConfig{ClusterProfile: "openshift-4.12", KubeVersion: "v1.25.4"})
+	assert.Contains(t, rendered, `kubeVersion: "v1.25.4"`)
+}
+
+func TestRenderWithLookup(t *testing.T) {
+	lookupDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(lookupDir, "secret.yaml"), []byte(lookupSecret), 0600))
+
+	rendered := renderTestChart(t, config.HelmConfig{LookupDirectory: lookupDir})
+	assert.Contains(t, rendered, `password: "c2VjcmV0"`)
+	assert.Contains(t, rendered, `owner: ""`)
+}
+
+func TestLenientTemplate(t *testing.T) {
+	template := `{{- define "app.owner" -}}
+{{ .Values.owner | required "owner is required" }}
+{{- end -}}
+owner: {{ include "app.owner" . }}
+{{- if not .Values.tls }}
+{{ fail "tls is required" }}
+{{- end }}
+required: {{ .Values.required }}
+`
+	assert.Equal(t, `{{- define "app.owner" -}}
+{{ .Values.owner | and "owner is required" }}
+{{- end -}}
+owner: {{ include "app.owner" . }}
+{{- if not .Values.tls }}
+{{ and "" "tls is required" }}
+{{- end }}
+required: {{ .Values.required }}
+`, string(lenientTemplate("templates/configmap.yaml", []byte(template))))
+
+	unparsable := []byte("{{ required ")
+	assert.Equal(t, unparsable, lenientTemplate("templates/configmap.yaml", unparsable))
+}
+
+func TestRenderWithPostRenderer(t *testing.T) {
+	postRenderer := filepath.Join(t.TempDir(), "post-renderer.sh")
+	require.NoError(t, os.WriteFile(postRenderer, []byte("#!/bin/sh\nsed \"s/name: app/name: $1/\"\n"), 0700))
+
+	rendered := renderTestChart(t, config.HelmConfig{PostRenderer: postRenderer, PostRendererArgs: []string{"post-rendered"}})
+	assert.Contains(t, rendered, "name: post-rendered")
+	assert.Contains(t, rendered, "# Source: app/templates/configmap.yaml")
+}
@@ -0,0 +1,294 @@
+package lintcontext
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	yamlv3 "gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// helmValueMarkerPrefix starts the markers that replace chart values to find where they are substituted.
+	// Markers are valid DNS labels, so that they can be used in object names.
+	helmValueMarkerPrefix = "kube-linter-value-"
+)
+
+// HelmTemplateMetadata locates the source of an object in the template of the Helm chart it was rendered from.
+type HelmTemplateMetadata struct {
+	// Path is the path of the template, relative to the chart, e.g. templates/deployment.yaml.
+	Path string
+	// StartLine and EndLine are the 1-based range of lines of the template that the object was rendered from,
+	// or 0 if the documents of the template can't be matched to the rendered ones.
+	StartLine int `json:",omitempty"`
+	EndLine   int `json:",omitempty"`
+	// Values maps the paths of the fields of the object that are a direct substitution of a chart value,
+	// e.g. spec.replicas, to the key of the value in values.yaml, e.g. replicaCount.
+	Values map[string]string `json:"-"`
+}
+
+// HelmValuesKey returns the key of the chart value that the field at the given 1-based line of Raw is a direct
+// substitution of, or "" if the object was not rendered from a Helm chart or the field is not a substitution.
+func (m *ObjectMetadata) HelmValuesKey(line int) string {
+	if m.HelmTemplate == nil || len(m.HelmTemplate.Values) == 0 || line <= 0 {
+		return ""
+	}
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(m.Raw, &doc); err != nil {
+		return ""
+	}
+	var key string
+	walkYAMLFields(&doc, "", func(fieldPath string, node *yamlv3.Node) {
+		if valuesKey, found := m.HelmTemplate.Values[fieldPath]; found && key == "" && node.Line == line {
+			key = valuesKey
+		}
+	})
+	return key
+}
+
+// HelmValuesKeyOfField returns the key of the chart value that the field at the given path, e.g.
+// spec.template.spec.containers[0].image, is a direct substitution of, or "" if the object was not rendered from
+// a Helm chart or the field is not a substitution.
+func (m *ObjectMetadata) HelmValuesKeyOfField(fieldPath string) string {
+	if m.HelmTemplate == nil || fieldPath == "" {
+		return ""
+	}
+	return m.HelmTemplate.Values[fieldPath]
+}
+
+// walkYAMLFields calls f with the path of every mapping value and sequence item under node,
+// e.g. spec.template.spec.containers[0].image.
+func walkYAMLFields(node *yamlv3.Node, fieldPath string, f func(fieldPath string, node *yamlv3.Node)) {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		for _, child := range node.Content {
+			walkYAMLFields(child, fieldPath, f)
+		}
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := node.Content[i].Value
+			if fieldPath != "" {
+				childPath = fieldPath + "." + childPath
+			}
+			f(childPath, node.Content[i+1])
+			walkYAMLFields(node.Content[i+1], childPath, f)
+		}
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+			f(itemPath, item)
+			walkYAMLFields(item, itemPath, f)
+		}
+	}
+}
+
+// helmTracer traces the objects rendered from a Helm chart back to the templates and values they come from.
+type helmTracer struct {
+	chartName string
+	// templates are the sources of the templates of the chart and its subcharts, by the path Helm renders them to.
+	templates map[string]string
+	// instrumented is the output of rendering the chart with its values replaced by markers, and markers maps
+	// the markers to the keys of the values they replaced. Both are empty if that rendering failed.
+	instrumented map[string]string
+	markers      map[string]string
+}
+
+func (l *lintContextImpl) newHelmTracer(chrt *chart.Chart) *helmTracer {
+	tracer := &helmTracer{chartName: chrt.Name(), templates: make(map[string]string)}
+	addHelmTemplates(chrt, tracer.templates)
+
+	// Helm doesn't have great logging behaviour, and can spam stderr, so silence their logging.
+	log.SetOutput(nopWriter{})
+	defer log.SetOutput(os.Stderr)
+	markers := make(map[string]string)
+	marked := markHelmValues(chrt.Values, "", markers)
+	markedValues, _ := marked.(map[string]interface{})
+	if markedValues == nil {
+		markedValues = make(map[string]interface{})
+	}
+	// The markers may break templates that expect values of another type, in which case values are not traced.
+	// The post-renderer, if any, is not run again for it.
+	if instrumented, err := l.renderTemplates(chrt, markedValues); err == nil {
+		tracer.instrumented = instrumented
+		tracer.markers = markers
+	}
+	return tracer
+}
+
+func addHelmTemplates(chrt *chart.Chart, templates map[string]string) {
+	for _, t := range chrt.Templates {
+		templates[path.Join(chrt.ChartFullPath(), t.Name)] = string(t.Data)
+	}
+	for _, dependency := range chrt.Dependencies() {
+		addHelmTemplates(dependency, templates)
+	}
+}
+
+// markHelmValues returns a copy of value with every value that is set replaced by a marker, and records the keys
+// of the replaced values by marker. Values that are empty, false or zero are kept, so that the templates take
+// the same branches as with the actual values.
+func markHelmValues(value interface{}, key string, markers map[string]string) interface{} {
+	switch value := value.(type) {
+	case chartutil.Values:
+		return markHelmValues(map[string]interface{}(value), key, markers)
+	case map[string]interface{}:
+		marked := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			childKey := k
+			if key != "" {
+				childKey = key + "." + k
+			}
+			marked[k] = markHelmValues(v, childKey, markers)
+		}
+		return marked
+	case []interface{}:
+		marked := make([]interface{}, 0, len(value))
+		for i, v := range value {
+			marked = append(marked, markHelmValues(v, fmt.Sprintf("%s[%d]", key, i), markers))
+		}
+		return marked
+	case nil, bool:
+		if value != true {
+			return value
+		}
+	default:
+		if s := fmt.Sprint(value); s == "" || s == "0" {
+			return value
+		}
+	}
+	marker := fmt.Sprintf("%s%d", helmValueMarkerPrefix, len(markers))
+	markers[marker] = key
+	return marker
+}
+
+// trace sets the template metadata of the objects loaded from the rendered output of the template at renderedPath.
+func (t *helmTracer) trace(renderedPath, rendered string, objects []Object) {
+	templatePath := renderedPath
+	if _, found := t.templates[templatePath]; !found {
+		// The chart name was stripped from the paths of charts loaded from a directory.
+		templatePath = path.Join(t.chartName, renderedPath)
+	}
+	source, found := t.templates[templatePath]
+	if !found {
+		return
+	}
+
+	ranges := templateDocumentRanges(source)
+	docs := splitYAMLDocuments(rendered)
+	instrumentedDocs := splitYAMLDocuments(t.instrumented[templatePath])
+	docIdx := 0
+	for i := range objects {
+		// Objects loaded from the same List share its document.
+		for docIdx < len(docs) && !bytes.Equal(docs[docIdx], objects[i].Metadata.Raw) {
+			docIdx++
+		}
+		if docIdx == len(docs) {
+			return
+		}
+		metadata := &HelmTemplateMetadata{Path: strings.TrimPrefix(templatePath, t.chartName+"/")}
+		// A template with a single document renders all its objects from it, e.g. in a range loop.
+		// Otherwise, the documents are only matched if none of them were left out by a condition.
+		switch len(ranges) {
+		case 1:
+			metadata.StartLine, metadata.EndLine = ranges[0].start, ranges[0].end
+		case len(docs):
+			metadata.StartLine, metadata.EndLine = ranges[docIdx].start, ranges[docIdx].end
+		}
+		if len(instrumentedDocs) == len(docs) {
+			metadata.Values = helmValueSubstitutions(instrumentedDocs[docIdx], docs[docIdx], t.markers)
+		}
+		objects[i].Metadata.HelmTemplate = metadata
+	}
+}
+
+// lineRange is a 1-based, inclusive range of lines.
+type lineRange struct {
+	start, end int
+}
+
+// templateDocumentRanges returns the ranges of the non-blank lines of the YAML documents of a template.
+func templateDocumentRanges(source string) []lineRange {
+	var ranges []lineRange
+	var current lineRange
+	for i, line := range strings.Split(source, "\n") {
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(line[len("---"):]) == "" {
+			if current.start > 0 {
+				ranges = append(ranges, current)
+			}
+			current = lineRange{}
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if current.start == 0 {
+			current.start = i + 1
+		}
+		current.end = i + 1
+	}
+	if current.start > 0 {
+		ranges = append(ranges, current)
+	}
+	return ranges
+}
+
+// splitYAMLDocuments returns the non-empty documents of the given YAML, trimmed the same way as when loading objects.
+func splitYAMLDocuments(contents string) [][]byte {
+	var docs [][]byte
+	reader := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(contents)))
+	for {
+		doc, err := reader.Read()
+		if err != nil {
+			return docs
+		}
+		if doc = bytes.TrimSpace(doc); len(doc) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// helmValueSubstitutions returns the keys of the values that the fields of the given document rendered with
+// markers were substituted from, by field path. The markers can make templates take other branches than the actual
+// values do, so the substitutions are only returned if the document has the same fields as the rendered one.
+func helmValueSubstitutions(instrumented, rendered []byte, markers map[string]string) map[string]string {
+	var instrumentedNode, renderedNode yamlv3.Node
+	if err := yamlv3.Unmarshal(instrumented, &instrumentedNode); err != nil {
+		return nil
+	}
+	if err := yamlv3.Unmarshal(rendered, &renderedNode); err != nil {
+		return nil
+	}
+	if !yamlFieldPaths(&instrumentedNode).Equal(yamlFieldPaths(&renderedNode)) {
+		return nil
+	}
+	substitutions := make(map[string]string)
+	walkYAMLFields(&instrumentedNode, "", func(fieldPath string, node *yamlv3.Node) {
+		if node.Kind != yamlv3.ScalarNode {
+			return
+		}
+		if key, found := markers[node.Value]; found {
+			substitutions[fieldPath] = key
+		}
+	})
+	if len(substitutions) == 0 {
+		return nil
+	}
+	return substitutions
+}
+
+// yamlFieldPaths returns the paths of the fields of the given document.
+func yamlFieldPaths(node *yamlv3.Node) set.StringSet {
+	paths := set.NewStringSet()
+	walkYAMLFields(node, "", func(fieldPath string, _ *yamlv3.Node) {
+		paths.Add(fieldPath)
+	})
+	return paths
+}
@@ -0,0 +1,116 @@
+package lintcontext
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+func TestTemplateDocumentRanges(t *testing.T) {
+	source := `{{- if .Values.enabled }}
+apiVersion: v1
+kind: Service
+
+---
+apiVersion: v1
+kind: ConfigMap
+{{- end }}
+---
+`
+	assert.Equal(t, []lineRange{{start: 1, end: 3}, {start: 6, end: 8}}, templateDocumentRanges(source))
+}
+
+func TestMarkHelmValues(t *testing.T) {
+	markers := make(map[string]string)
+	marked := markHelmValues(map[string]interface{}{
+		"replicaCount": 2.0,
+		"image":        map[string]interface{}{"repository": "nginx", "tag": ""},
+		"hosts":        []interface{}{"a.example.com"},
+		"enabled":      false,
+		"debug":        true,
+		"port":         0.0,
+	}, "", markers)
+
+	assert.ElementsMatch(t, []string{"replicaCount", "image.repository", "hosts[0]", "debug"}, mapValues(markers))
+	values := marked.(map[string]interface{})
+	assert.Equal(t, "", values["image"].(map[string]interface{})["tag"])
+	assert.Equal(t, false, values["enabled"])
+	assert.Equal(t, 0.0, values["port"])
+	assert.Equal(t, "replicaCount", markers[values["replicaCount"].(string)])
+}
+
+func TestHelmValueSubstitutions(t *testing.T) {
+	markers := map[string]string{"kube-linter-value-0": "replicaCount", "kube-linter-value-1": "mode"}
+	rendered := []byte("spec:\n  replicas: 2\n  strategy:\n    type: Recreate")
+
+	substitutions := helmValueSubstitutions([]byte("spec:\n  replicas: kube-linter-value-0\n  strategy:\n    type: Recreate"), rendered, markers)
+	assert.Equal(t, map[string]string{"spec.replicas": "replicaCount"}, substitutions)
+
+	// A template that compares mode to "recreate" takes another branch with the marker than with the actual value.
+	substitutions = helmValueSubstitutions([]byte("spec:\n  replicas: kube-linter-value-0\n  paused: kube-linter-value-1"), rendered, markers)
+	assert.Nil(t, substitutions)
+}
+
+func TestHelmTemplateTracing(t *testing.T) {
+	lintCtxs, err := CreateContexts(chartDirectory)
+	require.NoError(t, err)
+	lintCtx := verifyAndGetContext(t, lintCtxs)
+
+	var hpa *Object
+	for i, obj := range lintCtx.Objects() {
+		if obj.K8sObject.GetObjectKind().GroupVersionKind().Kind == "HorizontalPodAutoscaler" {
+			hpa = &lintCtx.Objects()[i]
+		}
+	}
+	require.NotNil(t, hpa)
+	require.NotNil(t, hpa.Metadata.HelmTemplate)
+	assert.Equal(t, "templates/hpa.yaml", hpa.Metadata.HelmTemplate.Path)
+	assert.Equal(t, 1, hpa.Metadata.HelmTemplate.StartLine)
+	assert.Equal(t, 28, hpa.Metadata.HelmTemplate.EndLine)
+
+	lines := bytes.Split(hpa.Metadata.Raw, []byte("\n"))
+	for i, line := range lines {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("minReplicas:")) {
+			assert.Equal(t, "autoscaling.minReplicas", hpa.Metadata.HelmValuesKey(i+1))
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("kind:")) {
+			assert.Empty(t, hpa.Metadata.HelmValuesKey(i+1))
+		}
+	}
+	assert.Equal(t, "autoscaling.minReplicas", hpa.Metadata.HelmValuesKeyOfField("spec.minReplicas"))
+	assert.Empty(t, hpa.Metadata.HelmValuesKeyOfField("kind"))
+}
+
+func TestHelmTemplateTracingWithPostRenderer(t *testing.T) {
+	dir := t.TempDir()
+	postRenderer := filepath.Join(dir, "post-renderer.sh")
+	runs := filepath.Join(dir, "runs")
+	require.NoError(t, os.WriteFile(postRenderer, []byte("#!/bin/sh\necho run >> "+runs+"\ncat\n"), 0700))
+
+	lintCtxs, err := CreateContextsWithOptions(Options{Helm: config.HelmConfig{PostRenderer: postRenderer}}, chartDirectory)
+	require.NoError(t, err)
+	lintCtx := verifyAndGetContext(t, lintCtxs)
+	for _, obj := range lintCtx.Objects() {
+		if obj.K8sObject.GetObjectKind().GroupVersionKind().Kind == "HorizontalPodAutoscaler" {
+			assert.Equal(t, "autoscaling.minReplicas", obj.Metadata.HelmValuesKeyOfField("spec.minReplicas"))
+		}
+	}
+
+	// The chart is only post-rendered once, and not again to trace its values.
+	contents, err := os.ReadFile(runs)
+	require.NoError(t, err)
+	assert.Equal(t, "run\n", string(contents))
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,297 @@
+package lintcontext
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	y "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+const (
+	// DefaultHelmfileEnvironment is the environment that the releases of helmfiles are rendered for by default.
+	DefaultHelmfileEnvironment = "default"
+
+	helmfileTemplateSuffix = ".gotmpl"
+)
+
+var (
+	helmfileNames = set.NewFrozenStringSet("helmfile.yaml", "helmfile.yml", "helmfile.yaml.gotmpl", "helmfile.yml.gotmpl")
+)
+
+// isHelmfile returns whether the given file is a helmfile state file, i.e. a helmfile.yaml,
+// or a YAML file in a helmfile.d directory.
+func isHelmfile(path string) bool {
+	if helmfileNames.Contains(filepath.Base(path)) {
+		return true
+	}
+	ext := strings.TrimSuffix(strings.ToLower(path), helmfileTemplateSuffix)
+	return filepath.Base(filepath.Dir(path)) == "helmfile.d" && knownYAMLExtensions.Contains(filepath.Ext(ext))
+}
+
+// helmfileState is the part of a helmfile state file that is needed to render its releases.
+type helmfileState struct {
+	Environments map[string]helmfileEnvironment `json:"environments"`
+	Releases     []helmfileRelease              `json:"releases"`
+}
+
+type helmfileEnvironment struct {
+	// Values are paths of values files, relative to the helmfile, or inline values.
+	Values []interface{} `json:"values"`
+}
+
+type helmfileRelease struct {
+	Name      string        `json:"name"`
+	Namespace string        `json:"namespace"`
+	Chart     string        `json:"chart"`
+	Installed *bool         `json:"installed"`
+	Values    []interface{} `json:"values"`
+	Set       []helmfileSet `json:"set"`
+}
+
+type helmfileSet struct {
+	Name   string   `json:"name"`
+	Value  string   `json:"value"`
+	Values []string `json:"values"`
+}
+
+// helmfileContextName names the lint context of a release of a helmfile.
+func helmfileContextName(helmfilePath, release, environment string) string {
+	return fmt.Sprintf("%s#%s@%s", helmfilePath, release, environment)
+}
+
+// createHelmfileContexts renders the releases of the given helmfile for the given environment, and returns a lint
+// context for each of them by name. A helmfile that can't be loaded results in a single context with an invalid object.
+func createHelmfileContexts(options Options, helmfilePath string) map[string]*lintContextImpl {
+	environment := options.HelmfileEnvironment
+	if environment == "" {
+		environment = DefaultHelmfileEnvironment
+	}
+	state, environmentValues, err := loadHelmfile(helmfilePath, environment)
+	if err != nil {
+		ctx := newCtx(options)
+		ctx.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: helmfilePath}, LoadErr: err})
+		return map[string]*lintContextImpl{helmfilePath: ctx}
+	}
+
+	contexts := make(map[string]*lintContextImpl, len(state.Releases))
+	for _, release := range state.Releases {
+		if release.Installed != nil && !*release.Installed {
+			continue
+		}
+		name := helmfileContextName(helmfilePath, release.Name, environment)
+		ctx := newCtx(options)
+		ctx.helmRelease = chartutil.ReleaseOptions{Name: release.Name, Namespace: release.Namespace}
+		if ctx.helmRelease.Namespace == "" {
+			ctx.helmRelease.Namespace = "default"
+		}
+		if err := ctx.loadObjectsFromHelmfileRelease(helmfilePath, release, environment, environmentValues); err != nil {
+			ctx.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: name}, LoadErr: err})
+		}
+		for i := range ctx.objects {
+			ctx.objects[i].Metadata.HelmChart.Release = name
+		}
+		contexts[name] = ctx
+	}
+	return contexts
+}
+
+// loadHelmfile loads the releases of a helmfile, and the values of the given environment. Like helmfile does,
+// each document of the state file is rendered as a template with the environment values of the previous ones.
+func loadHelmfile(helmfilePath, environment string) (*helmfileState, map[string]interface{}, error) {
+	contents, err := os.ReadFile(filepath.Clean(helmfilePath))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "reading helmfile %s", helmfilePath)
+	}
+	dir := filepath.Dir(helmfilePath)
+	state := &helmfileState{}
+	environmentValues := make(map[string]interface{})
+	environmentFound := environment == DefaultHelmfileEnvironment
+	for i, doc := range splitHelmfileDocuments(string(contents)) {
+		rendered, err := renderHelmfileTemplate(dir, doc, helmfileTemplateData(environment, environmentValues, nil))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "rendering document %d of helmfile %s", i, helmfilePath)
+		}
+		var part helmfileState
+		if err := y.Unmarshal(rendered, &part); err != nil {
+			return nil, nil, errors.Wrapf(err, "parsing document %d of helmfile %s", i, helmfilePath)
+		}
+		if env, found := part.Environments[environment]; found {
+			environmentFound = true
+			values, err := mergeHelmfileValues(dir, env.Values, helmfileTemplateData(environment, environmentValues, nil))
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "loading the values of environment %q", environment)
+			}
+			environmentValues = chartutil.CoalesceTables(values, environmentValues)
+		}
+		state.Releases = append(state.Releases, part.Releases...)
+	}
+	if !environmentFound {
+		return nil, nil, errors.Errorf("environment %q is not defined in helmfile %s", environment, helmfilePath)
+	}
+	return state, environmentValues, nil
+}
+
+// loadObjectsFromHelmfileRelease renders a release of a helmfile. The values of the release take precedence over the
+// defaults of the chart.
+func (l *lintContextImpl) loadObjectsFromHelmfileRelease(helmfilePath string, release helmfileRelease, environment string, environmentValues map[string]interface{}) error {
+	if release.Name == "" {
+		return errors.New("release has no name")
+	}
+	dir := filepath.Dir(helmfilePath)
+	chartDir := filepath.Join(dir, release.Chart)
+	if isLocal, _ := chartutil.IsChartDir(chartDir); !isLocal {
+		return errors.Errorf("chart %q of release %s is not a local chart directory, only local charts are supported", release.Chart, release.Name)
+	}
+
+	data := helmfileTemplateData(environment, environmentValues, &release)
+	values, err := mergeHelmfileValues(dir, release.Values, data)
+	if err != nil {
+		return errors.Wrapf(err, "loading the values of release %s", release.Name)
+	}
+	for _, setValue := range release.Set {
+		value := strings.ReplaceAll(setValue.Value, ",", `\,`)
+		if setValue.Values != nil {
+			value = "{" + strings.Join(setValue.Values, ",") + "}"
+		}
+		if err := strvals.ParseInto(setValue.Name+"="+value, values); err != nil {
+			return errors.Wrapf(err, "setting %s of release %s", setValue.Name, release.Name)
+		}
+	}
+	return l.loadObjectsFromChartRelease(chartDir, values)
+}
+
+// mergeHelmfileValues merges values files, relative to dir, and inline values, with the later ones taking precedence.
+// Values files whose name ends with .gotmpl are rendered as templates first.
+func mergeHelmfileValues(dir string, entries []interface{}, data map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	for _, entry := range entries {
+		var values map[string]interface{}
+		switch entry := entry.(type) {
+		case map[string]interface{}:
+			values = entry
+		case string:
+			valuesPath := filepath.Join(dir, entry)
+			contents, err := os.ReadFile(filepath.Clean(valuesPath))
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading values file %s", valuesPath)
+			}
+			if strings.HasSuffix(valuesPath, helmfileTemplateSuffix) {
+				if contents, err = renderHelmfileTemplate(dir, string(contents), data); err != nil {
+					return nil, errors.Wrapf(err, "rendering values file %s", valuesPath)
+				}
+			}
+			if err := y.Unmarshal(contents, &values); err != nil {
+				return nil, errors.Wrapf(err, "parsing values file %s", valuesPath)
+			}
+		default:
+			return nil, errors.Errorf("values must be paths of files or maps, got %v", entry)
+		}
+		if values != nil {
+			merged = chartutil.CoalesceTables(values, merged)
+		}
+	}
+	return merged, nil
+}
+
+// splitHelmfileDocuments splits a state file into its documents. The documents are templates, so they can't be
+// split by a YAML reader.
+func splitHelmfileDocuments(contents string) []string {
+	var docs []string
+	var current []string
+	for _, line := range strings.Split(contents, "\n") {
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(line[len("---"):]) == "" {
+			docs = append(docs, strings.Join(current, "\n"))
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	return append(docs, strings.Join(current, "\n"))
+}
+
+func helmfileTemplateData(environment string, environmentValues map[string]interface{}, release *helmfileRelease) map[string]interface{} {
+	data := map[string]interface{}{
+		"Environment": map[string]interface{}{"Name": environment, "Values": environmentValues},
+		"Values":      environmentValues,
+	}
+	if release != nil {
+		data["Release"] = map[string]interface{}{"Name": release.Name, "Namespace": release.Namespace, "Chart": release.Chart}
+		data["Namespace"] = release.Namespace
+	}
+	return data
+}
+
+// renderHelmfileTemplate renders a template of a helmfile, with the functions of helmfile that don't need
+// a cluster or the network. Like in helmfile, accessing a missing key is an error. Environment variables can't be
+// read, so that linting doesn't depend on the environment it runs in, and readFile only reads files under dir.
+func renderHelmfileTemplate(dir, text string, data map[string]interface{}) ([]byte, error) {
+	funcs := sprig.TxtFuncMap()
+	for _, name := range []string{"env", "expandenv", "getHostByName"} {
+		delete(funcs, name)
+	}
+	funcs["readFile"] = func(path string) (string, error) {
+		fullPath := filepath.Join(dir, path)
+		if rel, err := filepath.Rel(dir, fullPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", errors.Errorf("readFile can only read files under %s, not %s", dir, path)
+		}
+		contents, err := os.ReadFile(fullPath)
+		return string(contents), err
+	}
+	funcs["toYaml"] = func(v interface{}) (string, error) {
+		out, err := y.Marshal(v)
+		return string(out), err
+	}
+	funcs["fromYaml"] = func(s string) (map[string]interface{}, error) {
+		var out map[string]interface{}
+		err := y.Unmarshal([]byte(s), &out)
+		return out, err
+	}
+	funcs["get"] = getHelmfileValue
+	funcs["getOrNil"] = func(path string, obj interface{}) (interface{}, error) {
+		return getHelmfileValue(path, nil, obj)
+	}
+
+	tmpl, err := template.New("helmfile").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// getHelmfileValue implements the get function of helmfile, i.e. get "path.to.key" [default] obj, which returns
+// the value at the given path of obj, or the default if there is none.
+func getHelmfileValue(path string, args ...interface{}) (interface{}, error) {
+	var defaultValue, obj interface{}
+	switch len(args) {
+	case 1:
+		obj = args[0]
+	case 2:
+		defaultValue, obj = args[0], args[1]
+	default:
+		return nil, errors.Errorf("get takes a path, an optional default value and an object, got %d arguments", len(args)+1)
+	}
+	current := obj
+	for _, key := range strings.Split(path, ".") {
+		asMap, ok := current.(map[string]interface{})
+		if !ok {
+			return defaultValue, nil
+		}
+		if current, ok = asMap[key]; !ok {
+			return defaultValue, nil
+		}
+	}
+	return current, nil
+}
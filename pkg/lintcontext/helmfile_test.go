@@ -0,0 +1,78 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+const (
+	helmfilePath = "../../tests/testdata/helmfile/helmfile.yaml"
+)
+
+func TestHelmfileReleases(t *testing.T) {
+	for _, testCase := range []struct {
+		flag, environment string
+		replicas          int32
+	}{
+		{flag: "", environment: DefaultHelmfileEnvironment, replicas: 1},
+		{flag: "production", environment: "production", replicas: 3},
+	} {
+		t.Run(testCase.environment, func(t *testing.T) {
+			lintCtxs, err := CreateContextsWithOptions(Options{HelmfileEnvironment: testCase.flag}, helmfilePath)
+			require.NoError(t, err)
+			lintCtx := verifyAndGetContext(t, lintCtxs)
+
+			var deployment *appsV1.Deployment
+			for _, obj := range lintCtx.Objects() {
+				assert.Equal(t, helmfileContextName(helmfilePath, "app", testCase.environment), obj.Metadata.HelmChart.Release)
+				// autoscaling is disabled by the set of the release.
+				assert.NotEqual(t, "HorizontalPodAutoscaler", obj.K8sObject.GetObjectKind().GroupVersionKind().Kind)
+				if d, ok := obj.K8sObject.(*appsV1.Deployment); ok {
+					deployment = d
+				}
+			}
+			require.NotNil(t, deployment)
+			assert.Equal(t, "app-mychart", deployment.Name)
+			require.NotNil(t, deployment.Spec.Replicas)
+			assert.Equal(t, testCase.replicas, *deployment.Spec.Replicas)
+			assert.Equal(t, map[string]string{"environment": testCase.environment}, deployment.Spec.Template.Annotations)
+		})
+	}
+}
+
+func TestHelmfileUnknownEnvironment(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{HelmfileEnvironment: "staging"}, helmfilePath)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	assert.Empty(t, lintCtxs[0].Objects())
+	require.Len(t, lintCtxs[0].InvalidObjects(), 1)
+	assert.Contains(t, lintCtxs[0].InvalidObjects()[0].LoadErr.Error(), `environment "staging" is not defined`)
+}
+
+func TestRenderHelmfileTemplateFunctions(t *testing.T) {
+	dir := t.TempDir()
+	helmfileDir := filepath.Join(dir, "helmfile")
+	require.NoError(t, os.MkdirAll(filepath.Join(helmfileDir, "values"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(helmfileDir, "values", "app.yaml"), []byte("replicas: 2"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o600))
+
+	rendered, err := renderHelmfileTemplate(helmfileDir, `{{ readFile "values/app.yaml" }}`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "replicas: 2", string(rendered))
+
+	for _, text := range []string{
+		`{{ readFile "../secret.txt" }}`,
+		`{{ readFile "values/../../secret.txt" }}`,
+		`{{ env "HOME" }}`,
+		`{{ requiredEnv "HOME" }}`,
+		`{{ expandenv "$HOME" }}`,
+	} {
+		_, err := renderHelmfileTemplate(helmfileDir, text, nil)
+		assert.Error(t, err, text)
+	}
+}
@@ -0,0 +1,164 @@
+package lintcontext
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	y "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// MarkdownSkipMarker is the marker that excludes a code block of a Markdown file from linting when it is in its
+	// info string, e.g. ```yaml kube-linter:skip.
+	MarkdownSkipMarker = "kube-linter:skip"
+)
+
+var (
+	markdownExtensions = set.NewFrozenStringSet(".md", ".markdown")
+	markdownYAMLInfo   = set.NewFrozenStringSet("yaml", "yml")
+)
+
+// isMarkdownFile returns whether the given file is a Markdown file.
+func isMarkdownFile(path string) bool {
+	return markdownExtensions.Contains(strings.ToLower(filepath.Ext(path)))
+}
+
+// markdownCodeBlock is a fenced code block of a Markdown file.
+type markdownCodeBlock struct {
+	// info is the info string after the opening fence, e.g. yaml.
+	info    string
+	content string
+	// line is the 1-based line of the file at which the content starts.
+	line int
+}
+
+// isLinted returns whether the block is YAML that isn't marked to be skipped.
+func (b *markdownCodeBlock) isLinted() bool {
+	fields := strings.Fields(b.info)
+	if len(fields) == 0 || !markdownYAMLInfo.Contains(strings.ToLower(fields[0])) {
+		return false
+	}
+	for _, field := range fields[1:] {
+		if field == MarkdownSkipMarker {
+			return false
+		}
+	}
+	return true
+}
+
+// extractMarkdownCodeBlocks returns the fenced code blocks of a Markdown document, i.e. the lines between an opening
+// fence of three or more backticks or tildes and a closing fence of the same character that is at least as long.
+// Like in CommonMark, the indentation of the opening fence is removed from the content, and a block that isn't
+// closed runs to the end of the document.
+func extractMarkdownCodeBlocks(contents string) []markdownCodeBlock {
+	var blocks []markdownCodeBlock
+	var current *markdownCodeBlock
+	var fence, indent string
+	var content []string
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if current == nil {
+			fenceLength := len(trimmed) - len(strings.TrimLeft(trimmed, "`"))
+			if fenceLength < 3 {
+				fenceLength = len(trimmed) - len(strings.TrimLeft(trimmed, "~"))
+			}
+			if fenceLength < 3 {
+				continue
+			}
+			info := strings.TrimSpace(trimmed[fenceLength:])
+			if trimmed[0] == '`' && strings.Contains(info, "`") {
+				// Backtick fences can't have backticks in their info string, so this is inline code.
+				continue
+			}
+			current = &markdownCodeBlock{info: info, line: i + 2}
+			fence, indent, content = trimmed[:fenceLength], line[:len(line)-len(trimmed)], nil
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+			current.content = strings.Join(content, "\n")
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		for j := 0; j < len(indent) && strings.HasPrefix(line, " "); j++ {
+			line = line[1:]
+		}
+		content = append(content, line)
+	}
+	if current != nil {
+		current.content = strings.Join(content, "\n")
+		blocks = append(blocks, *current)
+	}
+	return blocks
+}
+
+// loadObjectsFromMarkdownFile loads the objects of the YAML code blocks of a Markdown file. Documents of the blocks
+// that don't have an apiVersion and a kind are not manifests, e.g. snippets of values files, and are ignored.
+func (l *lintContextImpl) loadObjectsFromMarkdownFile(filePath string, info os.FileInfo) error {
+	if info.Size() > maxFileSizeBytes {
+		return nil
+	}
+	contents, err := os.ReadFile(filepath.Clean(filePath))
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", filePath)
+	}
+	for _, block := range extractMarkdownCodeBlocks(string(contents)) {
+		if !block.isLinted() {
+			continue
+		}
+		source := []byte(block.content)
+		reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(source)))
+		locator := &lineLocator{source: source, line: block.line - 1}
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				l.addInvalidObjects(InvalidObject{
+					Metadata: ObjectMetadata{FilePath: filePath, LineNumber: block.line},
+					LoadErr:  errors.Wrap(err, "reading code block"),
+				})
+				break
+			}
+			doc = bytes.TrimSpace(doc)
+			if !isManifest(doc) {
+				continue
+			}
+
+			metadata := ObjectMetadata{
+				FilePath:   filePath,
+				LineNumber: locator.locate(doc),
+				Raw:        doc,
+			}
+			objs, err := parseObjects(doc, l.customDecoder)
+			if err != nil {
+				l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: err})
+				continue
+			}
+			for _, obj := range objs {
+				l.addObjects(Object{Metadata: metadata, K8sObject: obj})
+			}
+		}
+	}
+	return nil
+}
+
+// isManifest returns whether the given YAML document is a mapping with an apiVersion and a kind.
+func isManifest(doc []byte) bool {
+	var fields map[string]interface{}
+	if err := y.Unmarshal(doc, &fields); err != nil {
+		return false
+	}
+	_, hasAPIVersion := fields["apiVersion"]
+	_, hasKind := fields["kind"]
+	return hasAPIVersion && hasKind
+}
@@ -0,0 +1,81 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	runbook = "# Runbook\n" +
+		"\n" +
+		"Deploy the app:\n" +
+		"\n" +
+		"```yaml\n" +
+		"apiVersion: v1\n" +
+		"kind: ServiceAccount\n" +
+		"metadata:\n" +
+		"  name: app\n" +
+		"---\n" +
+		"apiVersion: v1\n" +
+		"kind: ConfigMap\n" +
+		"metadata:\n" +
+		"  name: app\n" +
+		"```\n" +
+		"\n" +
+		"1. Don't do this:\n" +
+		"\n" +
+		"   ```yaml kube-linter:skip\n" +
+		"   apiVersion: v1\n" +
+		"   kind: Pod\n" +
+		"   metadata:\n" +
+		"     name: bad\n" +
+		"   ```\n" +
+		"\n" +
+		"2. Set the values:\n" +
+		"\n" +
+		"   ~~~yml\n" +
+		"   replicaCount: 2\n" +
+		"   ~~~\n" +
+		"\n" +
+		"   ~~~yml\n" +
+		"   apiVersion: v1\n" +
+		"   kind: Secret\n" +
+		"   metadata:\n" +
+		"     name: app\n" +
+		"   ~~~\n" +
+		"\n" +
+		"```bash\n" +
+		"kubectl apply -f app.yaml\n" +
+		"```\n"
+)
+
+func TestMarkdownCodeBlocks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "RUNBOOK.md")
+	require.NoError(t, os.WriteFile(path, []byte(runbook), 0600))
+
+	lintCtxs, err := CreateContextsWithOptions(Options{Markdown: true}, dir)
+	require.NoError(t, err)
+	lintCtx := verifyAndGetContext(t, lintCtxs)
+	assert.Empty(t, lintCtx.InvalidObjects())
+
+	lines := make(map[string]int)
+	for _, obj := range lintCtx.Objects() {
+		assert.Equal(t, path, obj.Metadata.FilePath)
+		lines[obj.K8sObject.GetObjectKind().GroupVersionKind().Kind] = obj.Metadata.LineNumber
+	}
+	assert.Equal(t, map[string]int{"ServiceAccount": 6, "ConfigMap": 11, "Secret": 33}, lines)
+}
+
+func TestMarkdownIsOptIn(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(runbook), 0600))
+
+	lintCtxs, err := CreateContextsWithOptions(Options{}, dir)
+	require.NoError(t, err)
+	assert.Empty(t, lintCtxs)
+}
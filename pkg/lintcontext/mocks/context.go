@@ -1,30 +1,109 @@
 package mocks
 
 import (
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/extract"
 	"golang.stackrox.io/kube-linter/pkg/k8sutil"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 )
 
 // MockLintContext is mock implementation of the LintContext used in unit tests
 type MockLintContext struct {
-	objects map[string]k8sutil.Object
+	objects  map[string]k8sutil.Object
+	raw      map[string][]byte
+	helm     map[string]helmOrigin
+	previous []k8sutil.Object
+
+	nodePools []config.NodePool
+}
+
+// helmOrigin is the Helm chart and template an object was rendered from.
+type helmOrigin struct {
+	filePath string
+	chart    *lintcontext.HelmChartMetadata
 }
 
 // Objects returns all the objects under this MockLintContext
 func (l *MockLintContext) Objects() []lintcontext.Object {
 	result := make([]lintcontext.Object, 0, len(l.objects))
-	for _, p := range l.objects {
-		result = append(result, lintcontext.Object{Metadata: lintcontext.ObjectMetadata{}, K8sObject: p})
+	for name, p := range l.objects {
+		metadata := lintcontext.ObjectMetadata{Raw: l.raw[name]}
+		extract.RecordNativeSidecars(p, metadata.Raw)
+		if origin, found := l.helm[name]; found {
+			metadata.FilePath = origin.filePath
+			metadata.HelmChart = origin.chart
+		}
+		result = append(result, lintcontext.Object{Metadata: metadata, K8sObject: p})
 	}
 	return result
 }
 
+// AddObject adds the given object to the context under the given name.
+func (l *MockLintContext) AddObject(name string, obj k8sutil.Object) {
+	l.objects[name] = obj
+}
+
+// SetRaw sets the source of the object with the given name, for checks that read fields that are missing
+// from the Kubernetes API types. The restartPolicy of native sidecars is read from it like when objects are loaded.
+func (l *MockLintContext) SetRaw(name string, raw []byte) {
+	l.raw[name] = raw
+}
+
+// SetHelmChart marks the object with the given name as rendered from the given template of the given Helm chart,
+// for checks that only apply to objects of Helm charts.
+func (l *MockLintContext) SetHelmChart(name, filePath string, chart *lintcontext.HelmChartMetadata) {
+	l.helm[name] = helmOrigin{filePath: filePath, chart: chart}
+}
+
 // InvalidObjects is not implemented. For now we don't care about invalid objects for mock context.
 func (l *MockLintContext) InvalidObjects() []lintcontext.InvalidObject {
 	return nil
 }
 
+// AddPreviousObject adds an object to the previous revision of the manifests, which the objects of the context
+// are compared to by transition checks. Objects are paired by their API group, kind, namespace and name.
+func (l *MockLintContext) AddPreviousObject(obj k8sutil.Object) {
+	l.previous = append(l.previous, obj)
+}
+
+// PreviousObject returns the object of the previous revision that the given object replaces.
+func (l *MockLintContext) PreviousObject(obj lintcontext.Object) (lintcontext.Object, bool) {
+	key := lintcontext.KeyOf(obj.K8sObject)
+	for _, previous := range l.previous {
+		if lintcontext.KeyOf(previous) == key {
+			return lintcontext.Object{K8sObject: previous}, true
+		}
+	}
+	return lintcontext.Object{}, false
+}
+
+// RemovedObjects returns the objects of the previous revision that have no counterpart in the context.
+func (l *MockLintContext) RemovedObjects() []lintcontext.Object {
+	var removed []lintcontext.Object
+	for _, previous := range l.previous {
+		if current, found := l.objects[previous.GetName()]; found && lintcontext.KeyOf(current) == lintcontext.KeyOf(previous) {
+			continue
+		}
+		removed = append(removed, lintcontext.Object{K8sObject: previous})
+	}
+	return removed
+}
+
+// SetNodePools sets the node pools of the cluster the objects of the context are deployed to.
+func (l *MockLintContext) SetNodePools(nodePools ...config.NodePool) {
+	l.nodePools = nodePools
+}
+
+// NodePools returns the node pools of the cluster the objects of the context are deployed to.
+func (l *MockLintContext) NodePools() []config.NodePool {
+	return l.nodePools
+}
+
 // NewMockContext returns an empty mockLintContext
 func NewMockContext() *MockLintContext {
-	return &MockLintContext{objects: make(map[string]k8sutil.Object)}
+	return &MockLintContext{
+		objects: make(map[string]k8sutil.Object),
+		raw:     make(map[string][]byte),
+		helm:    make(map[string]helmOrigin),
+	}
 }
@@ -13,12 +13,12 @@ import (
 	y "github.com/ghodss/yaml"
 	ocsAppsV1 "github.com/openshift/api/apps/v1"
 	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/extract"
 	"golang.stackrox.io/kube-linter/pkg/k8sutil"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli/values"
-	"helm.sh/helm/v3/pkg/engine"
 	autoscalingV2Beta1 "k8s.io/api/autoscaling/v2beta1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,6 +67,7 @@ func parseObjects(data []byte, d runtime.Decoder) ([]k8sutil.Object, error) {
 			if asK8sObj == nil {
 				return nil, errors.Errorf("object was not a k8s object: %v", obj)
 			}
+			extract.RecordNativeSidecars(asK8sObj, item.Raw)
 			objs = append(objs, asK8sObj)
 		}
 		return objs, nil
@@ -75,6 +76,7 @@ func parseObjects(data []byte, d runtime.Decoder) ([]k8sutil.Object, error) {
 	if asK8sObj == nil {
 		return nil, errors.Errorf("object was not a k8s object: %v", obj)
 	}
+	extract.RecordNativeSidecars(asK8sObj, data)
 	// TODO: validate
 	return []k8sutil.Object{asK8sObj}, nil
 }
@@ -85,73 +87,142 @@ func (w nopWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-func (l *lintContextImpl) renderHelmChart(dir string) (map[string]string, error) {
+func (l *lintContextImpl) renderHelmChart(dir string) (*chart.Chart, map[string]string, error) {
 	// Helm doesn't have great logging behaviour, and can spam stderr, so silence their logging.
 	// TODO: capture these logs.
 	log.SetOutput(nopWriter{})
 	defer log.SetOutput(os.Stderr)
 	chrt, err := loader.Load(dir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if err := chrt.Validate(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	valOpts := &values.Options{ValueFiles: []string{filepath.Join(dir, "values.yaml")}}
 	values, err := valOpts.MergeValues(nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "loading values.yaml file")
+		return nil, nil, errors.Wrap(err, "loading values.yaml file")
+	}
+	rendered, err := l.renderValues(chrt, values)
+	if err != nil {
+		return nil, nil, err
 	}
-	return l.renderValues(chrt, values)
+	return chrt, rendered, nil
 }
 
 func (l *lintContextImpl) renderValues(chrt *chart.Chart, values map[string]interface{}) (map[string]string, error) {
-	valuesToRender, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{Name: "test-release", Namespace: "default"}, nil)
+	rendered, err := l.renderTemplates(chrt, values)
 	if err != nil {
 		return nil, err
 	}
+	return l.postRender(rendered)
+}
 
-	e := engine.Engine{LintMode: true}
-	rendered, err := e.Render(chrt, valuesToRender)
+// renderTemplates renders the templates of the chart with the given values, without running the post-renderer.
+func (l *lintContextImpl) renderTemplates(chrt *chart.Chart, values map[string]interface{}) (map[string]string, error) {
+	capabilities, err := l.helmCapabilities()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to render")
+		return nil, err
+	}
+	valuesToRender, err := chartutil.ToRenderValues(chrt, values, l.helmRelease, capabilities)
+	if err != nil {
+		return nil, err
 	}
 
+	render, release, err := l.helmRenderer()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rendered, err := render(chrt, valuesToRender)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render")
+	}
 	return rendered, nil
 }
 
 func (l *lintContextImpl) loadObjectsFromHelmChart(dir string) {
-	renderedFiles, err := l.renderHelmChart(dir)
+	chrt, renderedFiles, err := l.renderHelmChart(dir)
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: dir}, LoadErr: err})
 		return
 	}
 	// Paths returned by helm include redundant directory in front, therefore we strip it out.
-	l.loadHelmRenderedTemplates(dir, normalizeDirectoryPaths(renderedFiles))
+	l.loadHelmRenderedTemplates(dir, chrt, normalizeDirectoryPaths(renderedFiles))
+}
+
+// loadObjectsFromChartRelease renders a release of the chart in the given directory that is declared in another file,
+// e.g. a helmfile, with the same machinery as Helm charts in directories. The values of the release take precedence
+// over the defaults of the chart.
+func (l *lintContextImpl) loadObjectsFromChartRelease(chartDir string, values map[string]interface{}) error {
+	chrt, err := loader.Load(chartDir)
+	if err != nil {
+		return err
+	}
+	if err := chrt.Validate(); err != nil {
+		return err
+	}
+	// The values of the release become the defaults of the chart, so that objects are traced back to them.
+	chrt.Values = chartutil.CoalesceTables(values, chrt.Values)
+	rendered, err := l.renderValues(chrt, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	l.loadHelmRenderedTemplates(chartDir, chrt, normalizeDirectoryPaths(rendered))
+	return nil
 }
 
 func (l *lintContextImpl) loadObjectsFromTgzHelmChart(tgzFile string) {
-	renderedFiles, err := l.renderTgzHelmChart(tgzFile)
+	chrt, renderedFiles, err := l.renderTgzHelmChart(tgzFile)
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: tgzFile}, LoadErr: err})
 		return
 	}
-	l.loadHelmRenderedTemplates(tgzFile, renderedFiles)
+	l.loadHelmRenderedTemplates(tgzFile, chrt, renderedFiles)
 }
 
-func (l *lintContextImpl) renderTgzHelmChart(tgzFile string) (map[string]string, error) {
+func (l *lintContextImpl) renderTgzHelmChart(tgzFile string) (*chart.Chart, map[string]string, error) {
 	log.SetOutput(nopWriter{})
 	defer log.SetOutput(os.Stderr)
 
 	chrt, err := loader.LoadFile(tgzFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	rendered, err := l.renderChart(tgzFile, chrt)
+	if err != nil {
+		return nil, nil, err
 	}
+	return chrt, rendered, nil
+}
 
-	return l.renderChart(tgzFile, chrt)
+// lineLocator finds the lines at which consecutive documents start in the source they were read from.
+type lineLocator struct {
+	source []byte
+	offset int
+	line   int
+}
+
+// locate returns the 1-based line at which doc starts, or 0 if doc is not found in the rest of the source
+// or the locator is nil.
+func (l *lineLocator) locate(doc []byte) int {
+	if l == nil {
+		return 0
+	}
+	idx := bytes.Index(l.source[l.offset:], doc)
+	if idx == -1 {
+		return 0
+	}
+	l.line += bytes.Count(l.source[l.offset:l.offset+idx], []byte("\n"))
+	startLine := l.line + 1
+	l.line += bytes.Count(doc, []byte("\n"))
+	l.offset += idx + len(doc)
+	return startLine
 }
 
-func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAMLReader) error {
+func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAMLReader, locator *lineLocator) error {
 	doc, err := r.Read()
 	if err != nil {
 		return err
@@ -162,8 +233,9 @@ func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAML
 	}
 
 	metadata := ObjectMetadata{
-		FilePath: filePath,
-		Raw:      doc,
+		FilePath:   filePath,
+		LineNumber: locator.locate(doc),
+		Raw:        doc,
 	}
 
 	objs, err := parseObjects(doc, l.customDecoder)
@@ -199,9 +271,19 @@ func (l *lintContextImpl) loadObjectsFromYAMLFile(filePath string, info os.FileI
 }
 
 func (l *lintContextImpl) loadObjectsFromReader(filePath string, reader io.Reader) error {
-	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+	source, err := io.ReadAll(reader)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", filePath)
+	}
+	yamlReader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(source)))
+	return l.loadObjectsFromYAMLReader(filePath, yamlReader, &lineLocator{source: source})
+}
+
+// loadObjectsFromYAMLReader loads all objects from the given reader. The locator may be nil if
+// line numbers are not meaningful, e.g. for rendered Helm templates.
+func (l *lintContextImpl) loadObjectsFromYAMLReader(filePath string, yamlReader *yaml.YAMLReader, locator *lineLocator) error {
 	for {
-		if err := l.loadObjectFromYAMLReader(filePath, yamlReader); err != nil {
+		if err := l.loadObjectFromYAMLReader(filePath, yamlReader, locator); err != nil {
 			if err == io.EOF {
 				return nil
 			}
@@ -236,29 +318,41 @@ func (l *lintContextImpl) renderChart(fileName string, chart *chart.Chart) (map[
 	return l.renderValues(chart, values)
 }
 
-func (l *lintContextImpl) renderTgzHelmChartReader(fileName string, tgzReader io.Reader) (map[string]string, error) {
+func (l *lintContextImpl) renderTgzHelmChartReader(fileName string, tgzReader io.Reader) (*chart.Chart, map[string]string, error) {
 	// Helm doesn't have great logging behaviour, and can spam stderr, so silence their logging.
 	log.SetOutput(nopWriter{})
 	defer log.SetOutput(os.Stderr)
 
 	chrt, err := loader.LoadArchive(tgzReader)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return l.renderChart(fileName, chrt)
+	rendered, err := l.renderChart(fileName, chrt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return chrt, rendered, nil
 }
 
 func (l *lintContextImpl) readObjectsFromTgzHelmChart(fileName string, tgzReader io.Reader) {
-	renderedFiles, err := l.renderTgzHelmChartReader(fileName, tgzReader)
+	chrt, renderedFiles, err := l.renderTgzHelmChartReader(fileName, tgzReader)
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: fileName}, LoadErr: err})
 		return
 	}
-	l.loadHelmRenderedTemplates(fileName, renderedFiles)
+	l.loadHelmRenderedTemplates(fileName, chrt, renderedFiles)
 }
 
-func (l *lintContextImpl) loadHelmRenderedTemplates(chartPath string, renderedFiles map[string]string) {
+func (l *lintContextImpl) loadHelmRenderedTemplates(chartPath string, chrt *chart.Chart, renderedFiles map[string]string) {
+	chartMetadata := &HelmChartMetadata{Path: chartPath}
+	if chrt.Metadata != nil {
+		chartMetadata.Name = chrt.Metadata.Name
+		chartMetadata.Annotations = chrt.Metadata.Annotations
+	}
+	firstObject := len(l.objects)
+	tracer := l.newHelmTracer(chrt)
+
 	for path, contents := range renderedFiles {
 		pathToTemplate := filepath.Join(chartPath, path)
 
@@ -267,10 +361,19 @@ func (l *lintContextImpl) loadHelmRenderedTemplates(chartPath string, renderedFi
 			continue
 		}
 
-		if err := l.loadObjectsFromReader(pathToTemplate, strings.NewReader(contents)); err != nil {
+		yamlReader := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(contents)))
+		firstFileObject := len(l.objects)
+		// Line numbers in the rendered output don't correspond to the template, so they are not tracked.
+		// Instead, the tracer locates the objects in the template.
+		if err := l.loadObjectsFromYAMLReader(pathToTemplate, yamlReader, nil); err != nil {
 			loadErr := errors.Wrapf(err, "loading object %s from rendered helm chart %s", pathToTemplate, chartPath)
 			l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: pathToTemplate}, LoadErr: loadErr})
 		}
+		tracer.trace(path, contents, l.objects[firstFileObject:])
+	}
+
+	for i := firstObject; i < len(l.objects); i++ {
+		l.objects[i].Metadata.HelmChart = chartMetadata
 	}
 }
 
@@ -0,0 +1,68 @@
+package lintcontext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+)
+
+func TestLineLocator(t *testing.T) {
+	source := []byte(`# leading comment
+---
+kind: A
+---
+
+kind: B
+metadata:
+  name: b
+---
+kind: A
+`)
+	locator := &lineLocator{source: source}
+	assert.Equal(t, 3, locator.locate([]byte("kind: A")))
+	assert.Equal(t, 6, locator.locate([]byte("kind: B\nmetadata:\n  name: b")))
+	assert.Equal(t, 10, locator.locate([]byte("kind: A")))
+	assert.Equal(t, 0, locator.locate([]byte("kind: C")))
+}
+
+func TestParseObjectsNativeSidecars(t *testing.T) {
+	objs, err := parseObjects([]byte(`
+apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: with-sidecar
+  spec:
+    template:
+      spec:
+        initContainers:
+        - name: proxy
+          restartPolicy: Always
+        containers:
+        - name: app
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: without-sidecar
+  spec:
+    template:
+      spec:
+        initContainers:
+        - name: proxy
+        containers:
+        - name: app
+`), nil)
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	podSpec, found := extract.PodSpec(objs[0])
+	require.True(t, found)
+	assert.True(t, podSpec.IsNativeSidecar("proxy"))
+	podSpec, found = extract.PodSpec(objs[1])
+	require.True(t, found)
+	assert.False(t, podSpec.IsNativeSidecar("proxy"))
+}
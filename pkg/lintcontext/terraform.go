@@ -0,0 +1,403 @@
+package lintcontext
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	y "github.com/ghodss/yaml"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+const (
+	terraformExtension = ".tf"
+)
+
+// isTerraformFile returns whether the given file is a Terraform configuration file.
+func isTerraformFile(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == terraformExtension
+}
+
+// terraformContextName names the lint context of a helm_release resource of a Terraform file.
+func terraformContextName(path, release string) string {
+	return fmt.Sprintf("%s#helm_release.%s", path, release)
+}
+
+// loadObjectsFromTerraformFile loads the objects that the kubernetes_manifest resources, and the typed resources of
+// the kubernetes provider such as kubernetes_deployment_v1, of the given Terraform file declare. The local charts of
+// its helm_release resources are rendered in contexts of their own, which are returned by name.
+// Expressions that can only be evaluated by Terraform, e.g. variables, are left out and noted.
+func (l *lintContextImpl) loadObjectsFromTerraformFile(options Options, path string) map[string]*lintContextImpl {
+	if l.terraformModule == nil {
+		l.terraformModule = loadTerraformModule(filepath.Dir(path))
+	}
+	file := l.terraformModule.file(path)
+	if file.readErr != nil {
+		l.addInvalidObjects(InvalidObject{
+			Metadata: ObjectMetadata{FilePath: path},
+			LoadErr:  errors.Wrapf(file.readErr, "reading Terraform file %s", path),
+		})
+		return nil
+	}
+	if file.diags.HasErrors() {
+		// The file is not valid Terraform, but it is not an invalid object either.
+		l.addNotes(Note{
+			Metadata: ObjectMetadata{FilePath: path},
+			Message:  fmt.Sprintf("Kubernetes objects of the file are not linted, since it could not be parsed: %v", file.diags),
+		})
+		return nil
+	}
+
+	evaluator := newTerraformEvaluator(l.terraformModule.dir, l.terraformModule.locals, l.terraformModule.sources)
+	releaseCtxs := make(map[string]*lintContextImpl)
+	for _, block := range terraformBlocks(file.body, "resource") {
+		if len(block.Labels) != 2 {
+			continue
+		}
+		resourceType, address := block.Labels[0], strings.Join(block.Labels, ".")
+		metadata := ObjectMetadata{FilePath: path, LineNumber: block.TypeRange.Start.Line}
+		_, _, isTyped := terraformResourceKind(resourceType)
+		switch {
+		case resourceType == "kubernetes_manifest":
+			manifest := block.Body.Attributes["manifest"]
+			if manifest == nil {
+				continue
+			}
+			obj, err := evaluator.evaluate(manifest.Expr, "")
+			l.addTerraformObject(metadata, address, obj, err, evaluator)
+		case isTyped:
+			obj, err := evaluator.evaluateTypedResource(resourceType, block.Body)
+			l.addTerraformObject(metadata, address, obj, err, evaluator)
+		case resourceType == "helm_release":
+			name := terraformContextName(path, block.Labels[1])
+			if ctx := l.loadTerraformHelmRelease(options, metadata, address, name, block.Body, evaluator); ctx != nil {
+				releaseCtxs[name] = ctx
+			}
+		}
+		evaluator.omitted = nil
+	}
+	return releaseCtxs
+}
+
+// A terraformModule holds the Terraform files of a directory, which are read and parsed once for all of them, and
+// the local values that they declare.
+type terraformModule struct {
+	dir   string
+	files map[string]*terraformFile
+	// sources are the contents of the files, by path, which expressions are quoted from.
+	sources map[string][]byte
+	locals  map[string]hclsyntax.Expression
+}
+
+// A terraformFile is a parsed Terraform file, or the error reading or parsing it.
+type terraformFile struct {
+	body    *hclsyntax.Body
+	readErr error
+	diags   hcl.Diagnostics
+}
+
+// loadTerraformModule reads and parses the Terraform files of the given directory.
+func loadTerraformModule(dir string) *terraformModule {
+	m := &terraformModule{
+		dir:     dir,
+		files:   make(map[string]*terraformFile),
+		sources: make(map[string][]byte),
+		locals:  make(map[string]hclsyntax.Expression),
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"+terraformExtension))
+	for _, path := range paths {
+		m.load(path)
+	}
+	return m
+}
+
+// file returns the given Terraform file of the module, which is loaded if it was not among the files of the module,
+// e.g. since its extension is not lowercase.
+func (m *terraformModule) file(path string) *terraformFile {
+	if file, found := m.files[filepath.Clean(path)]; found {
+		return file
+	}
+	return m.load(path)
+}
+
+func (m *terraformModule) load(path string) *terraformFile {
+	path = filepath.Clean(path)
+	file := &terraformFile{}
+	m.files[path] = file
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		file.readErr = err
+		return file
+	}
+	parsed, diags := hclsyntax.ParseConfig(contents, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		file.diags = diags
+		return file
+	}
+	file.body = parsed.Body.(*hclsyntax.Body)
+	m.sources[path] = contents
+	for _, block := range terraformBlocks(file.body, "locals") {
+		for name, attr := range block.Body.Attributes {
+			m.locals[name] = attr.Expr
+		}
+	}
+	return file
+}
+
+// terraformBlocks returns the blocks of the given type of a body.
+func terraformBlocks(body *hclsyntax.Body, blockType string) []*hclsyntax.Block {
+	var blocks []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		if block.Type == blockType {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// terraformAttributes returns the attributes of a body in the order they are declared.
+func terraformAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+	return attrs
+}
+
+// addTerraformObject adds the object that a resource evaluated to, with notes about its fields that were left out.
+// An object that is only invalid because of the fields that were left out is noted instead of being invalid.
+func (l *lintContextImpl) addTerraformObject(metadata ObjectMetadata, address string, value interface{}, evalErr error, evaluator *terraformEvaluator) {
+	if dynamicErr, ok := evalErr.(*dynamicExpressionError); ok {
+		l.addOmittedNotes(metadata, address, evaluator, nil)
+		l.addNotes(Note{
+			Metadata: metadata,
+			Message:  fmt.Sprintf("resource %s is not linted, since %v", address, dynamicErr),
+		})
+		return
+	}
+	if evalErr != nil {
+		l.addOmittedNotes(metadata, address, evaluator, nil)
+		l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: errors.Wrapf(evalErr, "evaluating resource %s", address)})
+		return
+	}
+
+	var objs []k8sutil.Object
+	data, err := json.Marshal(value)
+	if err == nil {
+		objs, err = parseObjects(data, l.customDecoder)
+	}
+	if err == nil {
+		// A resource declares a single object, unless it is a List.
+		var noted k8sutil.Object
+		if len(objs) == 1 {
+			noted = objs[0]
+		}
+		l.addOmittedNotes(metadata, address, evaluator, noted)
+		for _, obj := range objs {
+			l.addObjects(Object{Metadata: metadata, K8sObject: obj})
+		}
+		return
+	}
+	l.addOmittedNotes(metadata, address, evaluator, nil)
+	if len(evaluator.omitted) > 0 {
+		l.addNotes(Note{
+			Metadata: metadata,
+			Message:  fmt.Sprintf("resource %s is not linted, since it is not a valid object without the fields that were left out: %v", address, err),
+		})
+		return
+	}
+	l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: errors.Wrapf(err, "loading resource %s", address)})
+}
+
+// addOmittedNotes notes the fields of a resource that were left out since they can only be evaluated by Terraform.
+// The object of the resource is given if it was loaded without them.
+func (l *lintContextImpl) addOmittedNotes(metadata ObjectMetadata, address string, evaluator *terraformEvaluator, obj k8sutil.Object) {
+	for _, omitted := range evaluator.omitted {
+		l.addNotes(Note{
+			Metadata: ObjectMetadata{FilePath: metadata.FilePath, LineNumber: omitted.err.line},
+			Message:  fmt.Sprintf("%s of resource %s is left out, since %v", omitted.path, address, omitted.err),
+			Object:   obj,
+		})
+	}
+}
+
+// loadTerraformHelmRelease renders the local chart of a helm_release resource, with its values and set blocks, in a
+// context of its own. It returns nil, and notes why, if the chart can't be rendered without Terraform.
+func (l *lintContextImpl) loadTerraformHelmRelease(options Options, metadata ObjectMetadata, address, name string, body *hclsyntax.Body, evaluator *terraformEvaluator) *lintContextImpl {
+	notRendered := func(reason string) *lintContextImpl {
+		l.addNotes(Note{Metadata: metadata, Message: fmt.Sprintf("resource %s is not rendered, since %s", address, reason)})
+		return nil
+	}
+	chart, err := evaluateTerraformString(evaluator, body, "chart")
+	switch {
+	case err != nil:
+		return notRendered(err.Error())
+	case chart == "":
+		return notRendered("it has no chart")
+	case body.Attributes["repository"] != nil:
+		return notRendered(fmt.Sprintf("chart %s is in a repository, and only local charts are supported", chart))
+	}
+	chartDir := chart
+	if !filepath.IsAbs(chartDir) {
+		chartDir = filepath.Join(evaluator.dir, chart)
+	}
+	if isLocal, _ := chartutil.IsChartDir(chartDir); !isLocal {
+		return notRendered(fmt.Sprintf("chart %s is not a local chart directory, and only local charts are supported", chart))
+	}
+
+	ctx := newCtx(options)
+	if releaseName, err := evaluateTerraformString(evaluator, body, "name"); err == nil && releaseName != "" {
+		ctx.helmRelease.Name = releaseName
+	}
+	if namespace, err := evaluateTerraformString(evaluator, body, "namespace"); err == nil && namespace != "" {
+		ctx.helmRelease.Namespace = namespace
+	}
+	values, err := evaluateTerraformHelmValues(evaluator, body)
+	if err == nil {
+		err = ctx.loadObjectsFromChartRelease(chartDir, values)
+	}
+	if err != nil {
+		ctx.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: name}, LoadErr: err})
+	}
+	for i := range ctx.objects {
+		ctx.objects[i].Metadata.HelmChart.Release = name
+	}
+	ctx.addOmittedNotes(metadata, address, evaluator, nil)
+	return ctx
+}
+
+// evaluateTerraformString evaluates the given attribute of a body to a string, which is empty if the attribute is
+// not set.
+func evaluateTerraformString(evaluator *terraformEvaluator, body *hclsyntax.Body, name string) (string, error) {
+	attr := body.Attributes[name]
+	if attr == nil {
+		return "", nil
+	}
+	value, err := evaluator.evaluate(attr.Expr, name)
+	if err != nil {
+		return "", err
+	}
+	return templateString(value)
+}
+
+// evaluateTerraformHelmValues merges the values documents of a helm_release resource, with the later ones taking
+// precedence, and applies its set, set_list and set_sensitive blocks or attributes. Values that can only be
+// evaluated by Terraform are left out.
+func evaluateTerraformHelmValues(evaluator *terraformEvaluator, body *hclsyntax.Body) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	if attr := body.Attributes["values"]; attr != nil {
+		docs, err := evaluator.evaluate(attr.Expr, "values")
+		if evaluator.omit(err, "values") {
+			docs = nil
+		} else if err != nil {
+			return nil, err
+		}
+		docList, _ := docs.([]interface{})
+		for i, doc := range docList {
+			docString, err := templateString(doc)
+			if err != nil {
+				return nil, errors.Wrapf(err, "values[%d]", i)
+			}
+			var docValues map[string]interface{}
+			if err := y.Unmarshal([]byte(docString), &docValues); err != nil {
+				return nil, errors.Wrapf(err, "parsing values[%d]", i)
+			}
+			if docValues != nil {
+				values = chartutil.CoalesceTables(docValues, values)
+			}
+		}
+	}
+
+	for _, setType := range []string{"set", "set_list", "set_sensitive"} {
+		entries, err := evaluateTerraformHelmSets(evaluator, body, setType)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			name, _ := entry["name"].(string)
+			value, found := entry["value"]
+			if name == "" || !found {
+				// The entry has fields that can only be evaluated by Terraform, which are noted.
+				continue
+			}
+			if err := setTerraformHelmValue(values, name, value, entry["type"] == "string"); err != nil {
+				return nil, errors.Wrapf(err, "%s %s", setType, name)
+			}
+		}
+	}
+	return values, nil
+}
+
+// evaluateTerraformHelmSets evaluates the set blocks of the given type, or the attribute with a list of set
+// objects that newer versions of the helm provider use instead.
+func evaluateTerraformHelmSets(evaluator *terraformEvaluator, body *hclsyntax.Body, setType string) ([]map[string]interface{}, error) {
+	var entries []map[string]interface{}
+	if attr := body.Attributes[setType]; attr != nil {
+		list, err := evaluator.evaluate(attr.Expr, setType)
+		if evaluator.omit(err, setType) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		asList, _ := list.([]interface{})
+		for _, item := range asList {
+			if entry, ok := item.(map[string]interface{}); ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	for i, block := range terraformBlocks(body, setType) {
+		entry := make(map[string]interface{})
+		for _, attr := range terraformAttributes(block.Body) {
+			path := fmt.Sprintf("%s[%d].%s", setType, i, attr.Name)
+			value, err := evaluator.evaluate(attr.Expr, path)
+			if evaluator.omit(err, path) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			entry[attr.Name] = value
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// setTerraformHelmValue sets a value like helm's --set, --set-string and, for lists, --set with {a,b} do.
+func setTerraformHelmValue(values map[string]interface{}, name string, value interface{}, asString bool) error {
+	var valueString string
+	if list, ok := value.([]interface{}); ok {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			itemString, err := templateString(item)
+			if err != nil {
+				return err
+			}
+			items = append(items, strings.ReplaceAll(itemString, ",", `\,`))
+		}
+		valueString = "{" + strings.Join(items, ",") + "}"
+	} else {
+		str, err := templateString(value)
+		if err != nil {
+			return err
+		}
+		valueString = strings.ReplaceAll(str, ",", `\,`)
+	}
+	if asString {
+		return strvals.ParseIntoString(name+"="+valueString, values)
+	}
+	return strvals.ParseInto(name+"="+valueString, values)
+}
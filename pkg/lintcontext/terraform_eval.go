@@ -0,0 +1,569 @@
+package lintcontext
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	y "github.com/ghodss/yaml"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"golang.stackrox.io/kube-linter/internal/set"
+)
+
+// dynamicExpressionError is returned for expressions whose value is only known when Terraform runs, e.g. variables,
+// attributes of other resources and operations on them.
+type dynamicExpressionError struct {
+	// source is the part of the expression that can't be evaluated, e.g. a variable, and line is the line it is on.
+	source string
+	line   int
+}
+
+func (e *dynamicExpressionError) Error() string {
+	return fmt.Sprintf("`%s` on line %d can only be evaluated by Terraform", e.source, e.line)
+}
+
+// omittedField is a field of an object that was left out since its value is dynamic.
+type omittedField struct {
+	path string
+	err  *dynamicExpressionError
+}
+
+// terraformEvaluator evaluates the expressions of a Terraform module that don't depend on its variables or state,
+// to JSON-like values.
+type terraformEvaluator struct {
+	// dir is the directory of the module, which Terraform is assumed to run in.
+	dir    string
+	locals map[string]hclsyntax.Expression
+	// sources are the contents of the files of the module, by path, which expressions are quoted from.
+	sources   map[string][]byte
+	functions map[string]function.Function
+
+	evaluatingLocals set.StringSet
+	omitted          []omittedField
+}
+
+func newTerraformEvaluator(dir string, locals map[string]hclsyntax.Expression, sources map[string][]byte) *terraformEvaluator {
+	e := &terraformEvaluator{dir: dir, locals: locals, sources: sources, evaluatingLocals: set.NewStringSet()}
+	e.functions = terraformFunctions(e)
+	return e
+}
+
+// evaluate evaluates the given expression. Fields of objects and elements of tuples that are dynamic are left out
+// and recorded, while a dynamic expression that can't be left out results in a *dynamicExpressionError.
+func (e *terraformEvaluator) evaluate(expr hclsyntax.Expression, path string) (interface{}, error) {
+	switch expr := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		values := make([]interface{}, 0, len(expr.Exprs))
+		for i, element := range expr.Exprs {
+			value, err := e.evaluate(element, fmt.Sprintf("%s[%d]", path, i))
+			if e.omit(err, fmt.Sprintf("%s[%d]", path, i)) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case *hclsyntax.ObjectConsExpr:
+		values := make(map[string]interface{}, len(expr.Items))
+		for _, item := range expr.Items {
+			key, err := e.evaluate(item.KeyExpr, path)
+			if e.omit(err, joinFieldPath(path, e.source(item.KeyExpr.Range()))) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			keyString, err := templateString(key)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d: invalid object key", item.KeyExpr.Range().Start.Line)
+			}
+			value, err := e.evaluate(item.ValueExpr, joinFieldPath(path, keyString))
+			if e.omit(err, joinFieldPath(path, keyString)) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			values[keyString] = value
+		}
+		return values, nil
+	case *hclsyntax.FunctionCallExpr:
+		if !expr.ExpandFinal {
+			return e.call(expr, path)
+		}
+	}
+	return e.evaluateValue(expr, path)
+}
+
+// omit records the field at the given path as omitted if err is a *dynamicExpressionError.
+func (e *terraformEvaluator) omit(err error, path string) bool {
+	dynamicErr, ok := err.(*dynamicExpressionError)
+	if ok {
+		e.omitted = append(e.omitted, omittedField{path: path, err: dynamicErr})
+	}
+	return ok
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// source returns the source of the given range of a file of the module.
+func (e *terraformEvaluator) source(rng hcl.Range) string {
+	return string(rng.SliceBytes(e.sources[rng.Filename]))
+}
+
+func (e *terraformEvaluator) dynamic(rng hcl.Range) *dynamicExpressionError {
+	return &dynamicExpressionError{source: e.source(rng), line: rng.Start.Line}
+}
+
+// evaluateValue evaluates an expression as a whole, e.g. a template, a for expression or a conditional, with the
+// local values and functions of the module. References to anything else, e.g. variables or other resources, are
+// unknown, and make the expression dynamic if its value depends on them. Dynamic fields of the local values it refers
+// to are left out as fields of the given path.
+func (e *terraformEvaluator) evaluateValue(expr hclsyntax.Expression, path string) (interface{}, error) {
+	var unsupportedCall *hclsyntax.FunctionCallExpr
+	_ = hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && unsupportedCall == nil {
+			if _, found := e.functions[call.Name]; !found {
+				unsupportedCall = call
+			}
+		}
+		return nil
+	})
+	if unsupportedCall != nil {
+		return nil, e.dynamic(unsupportedCall.Range())
+	}
+
+	locals := make(map[string]cty.Value)
+	variables := map[string]cty.Value{
+		// Paths are resolved relative to the module directory by the file function.
+		"path": cty.ObjectVal(map[string]cty.Value{
+			"module": cty.StringVal("."),
+			"root":   cty.StringVal("."),
+			"cwd":    cty.StringVal("."),
+		}),
+	}
+	var dynamicRange *hcl.Range
+	for _, traversal := range hclsyntax.Variables(expr) {
+		switch root := traversal.RootName(); root {
+		case "path":
+		case "local":
+			if len(traversal) < 2 {
+				continue
+			}
+			attr, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			value, err := e.local(attr.Name, traversal.SourceRange().Start.Line, path)
+			if err != nil {
+				return nil, err
+			}
+			locals[attr.Name] = value
+		default:
+			variables[root] = cty.DynamicVal
+			if dynamicRange == nil {
+				rng := traversal.SourceRange()
+				dynamicRange = &rng
+			}
+		}
+	}
+	variables["local"] = cty.ObjectVal(locals)
+
+	value, diags := expr.Value(&hcl.EvalContext{Variables: variables, Functions: e.functions})
+	if diags.HasErrors() {
+		return nil, errors.Errorf("line %d: %s", expr.Range().Start.Line, diags.Error())
+	}
+	if !value.IsWhollyKnown() {
+		if dynamicRange == nil {
+			dynamicRange = expr.Range().Ptr()
+		}
+		return nil, e.dynamic(*dynamicRange)
+	}
+	return fromCtyValue(value)
+}
+
+// local evaluates the local value with the given name, which is referenced on the given line by the field at the
+// given path.
+func (e *terraformEvaluator) local(name string, line int, path string) (cty.Value, error) {
+	local, found := e.locals[name]
+	if !found {
+		return cty.NilVal, errors.Errorf("line %d: local value %s is not declared", line, name)
+	}
+	if e.evaluatingLocals.Contains(name) {
+		return cty.NilVal, errors.Errorf("line %d: local value %s refers to itself", line, name)
+	}
+	e.evaluatingLocals.Add(name)
+	value, err := e.evaluate(local, path)
+	e.evaluatingLocals.Remove(name)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return toCtyValue(value)
+}
+
+// call evaluates the calls of the Terraform functions that are used to declare manifests, with arguments whose
+// dynamic fields are left out. Calls of other functions are dynamic.
+func (e *terraformEvaluator) call(expr *hclsyntax.FunctionCallExpr, path string) (interface{}, error) {
+	fn, found := e.functions[expr.Name]
+	if !found {
+		return nil, e.dynamic(expr.Range())
+	}
+	args := make([]cty.Value, 0, len(expr.Args))
+	for _, argExpr := range expr.Args {
+		arg, err := e.evaluate(argExpr, path)
+		if err != nil {
+			return nil, err
+		}
+		value, err := toCtyValue(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+	}
+	value, err := fn.Call(args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "line %d: calling %s", expr.Range().Start.Line, expr.Name)
+	}
+	return fromCtyValue(value)
+}
+
+// toCtyValue converts a JSON-like value to the value that Terraform functions take.
+func toCtyValue(value interface{}) (cty.Value, error) {
+	if value == nil {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return jsonToCtyValue(data)
+}
+
+func jsonToCtyValue(data []byte) (cty.Value, error) {
+	ty, err := ctyjson.ImpliedType(data)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(data, ty)
+}
+
+// fromCtyValue converts a known value to a JSON-like value.
+func fromCtyValue(value cty.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	data, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// templateString converts a primitive value to a string, like Terraform does in interpolations.
+func templateString(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		return "", errors.Errorf("%v is not a string, a number or a bool", value)
+	}
+}
+
+// terraformFunctions returns the Terraform functions that are used to declare manifests, which are the functions
+// that can be evaluated. The file function reads files relative to the directory of the module.
+func terraformFunctions(e *terraformEvaluator) map[string]function.Function {
+	return map[string]function.Function{
+		"file": function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "path", Type: cty.String}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				path := args[0].AsString()
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(e.dir, path)
+				}
+				contents, err := os.ReadFile(filepath.Clean(path))
+				if err != nil {
+					return cty.NilVal, err
+				}
+				return cty.StringVal(string(contents)), nil
+			},
+		}),
+		"yamldecode": yamlDecodeFunc,
+		"yamlencode": yamlEncodeFunc,
+		"jsondecode": stdlib.JSONDecodeFunc,
+		"jsonencode": stdlib.JSONEncodeFunc,
+		"merge":      stdlib.MergeFunc,
+		"concat":     stdlib.ConcatFunc,
+		"tostring":   stdlib.MakeToFunc(cty.String),
+		"tolist":     stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+		"toset":      stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+		"tomap":      stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+		"lower":      stdlib.LowerFunc,
+		"upper":      stdlib.UpperFunc,
+		"trimspace":  stdlib.TrimSpaceFunc,
+	}
+}
+
+var (
+	yamlDecodeFunc = function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "src", Type: cty.String}},
+		Type: func(args []cty.Value) (cty.Type, error) {
+			if !args[0].IsKnown() {
+				return cty.DynamicPseudoType, nil
+			}
+			data, err := y.YAMLToJSON([]byte(args[0].AsString()))
+			if err != nil {
+				return cty.NilType, err
+			}
+			return ctyjson.ImpliedType(data)
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			data, err := y.YAMLToJSON([]byte(args[0].AsString()))
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return ctyjson.Unmarshal(data, retType)
+		},
+	})
+
+	yamlEncodeFunc = function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			data, err := ctyjson.Marshal(args[0], args[0].Type())
+			if err != nil {
+				return cty.NilVal, err
+			}
+			out, err := y.JSONToYAML(data)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cty.StringVal(string(out)), nil
+		},
+	})
+)
+
+// terraformResourceKinds maps the types of the Terraform resources of the kubernetes provider to the API version and
+// kind of the objects they declare. Types that aren't listed are looked up without their _v1 suffix.
+var terraformResourceKinds = map[string][2]string{
+	"kubernetes_cluster_role":                      {"rbac.authorization.k8s.io/v1", "ClusterRole"},
+	"kubernetes_cluster_role_binding":              {"rbac.authorization.k8s.io/v1", "ClusterRoleBinding"},
+	"kubernetes_config_map":                        {"v1", "ConfigMap"},
+	"kubernetes_cron_job":                          {"batch/v1beta1", "CronJob"},
+	"kubernetes_cron_job_v1":                       {"batch/v1", "CronJob"},
+	"kubernetes_daemon_set":                        {"apps/v1", "DaemonSet"},
+	"kubernetes_daemonset":                         {"apps/v1", "DaemonSet"},
+	"kubernetes_deployment":                        {"apps/v1", "Deployment"},
+	"kubernetes_horizontal_pod_autoscaler":         {"autoscaling/v1", "HorizontalPodAutoscaler"},
+	"kubernetes_horizontal_pod_autoscaler_v2":      {"autoscaling/v2", "HorizontalPodAutoscaler"},
+	"kubernetes_horizontal_pod_autoscaler_v2beta2": {"autoscaling/v2beta2", "HorizontalPodAutoscaler"},
+	"kubernetes_ingress":                           {"networking.k8s.io/v1beta1", "Ingress"},
+	"kubernetes_ingress_v1":                        {"networking.k8s.io/v1", "Ingress"},
+	"kubernetes_job":                               {"batch/v1", "Job"},
+	"kubernetes_namespace":                         {"v1", "Namespace"},
+	"kubernetes_network_policy":                    {"networking.k8s.io/v1", "NetworkPolicy"},
+	"kubernetes_persistent_volume_claim":           {"v1", "PersistentVolumeClaim"},
+	"kubernetes_pod":                               {"v1", "Pod"},
+	"kubernetes_pod_disruption_budget":             {"policy/v1beta1", "PodDisruptionBudget"},
+	"kubernetes_pod_disruption_budget_v1":          {"policy/v1", "PodDisruptionBudget"},
+	"kubernetes_replication_controller":            {"v1", "ReplicationController"},
+	"kubernetes_role":                              {"rbac.authorization.k8s.io/v1", "Role"},
+	"kubernetes_role_binding":                      {"rbac.authorization.k8s.io/v1", "RoleBinding"},
+	"kubernetes_secret":                            {"v1", "Secret"},
+	"kubernetes_service":                           {"v1", "Service"},
+	"kubernetes_service_account":                   {"v1", "ServiceAccount"},
+	"kubernetes_stateful_set":                      {"apps/v1", "StatefulSet"},
+}
+
+// terraformResourceKind returns the API version and kind of the objects of the given resource type, e.g.
+// kubernetes_deployment_v1.
+func terraformResourceKind(resourceType string) (apiVersion, kind string, found bool) {
+	gvk, found := terraformResourceKinds[resourceType]
+	if !found {
+		gvk, found = terraformResourceKinds[strings.TrimSuffix(resourceType, "_v1")]
+	}
+	return gvk[0], gvk[1], found
+}
+
+var (
+	// terraformListBlocks maps the types of the blocks of typed resources that declare an item of a list to the
+	// name of the list.
+	terraformListBlocks = map[string]string{
+		"container":          "containers",
+		"egress":             "egress",
+		"env":                "env",
+		"env_from":           "envFrom",
+		"from":               "from",
+		"host_aliases":       "hostAliases",
+		"http_header":        "httpHeaders",
+		"image_pull_secrets": "imagePullSecrets",
+		"ingress":            "ingress",
+		"init_container":     "initContainers",
+		"items":              "items",
+		"match_expressions":  "matchExpressions",
+		"metric":             "metrics",
+		"node_selector_term": "nodeSelectorTerms",
+		"path":               "paths",
+		"port":               "ports",
+		"ports":              "ports",
+		"preferred_during_scheduling_ignored_during_execution": "preferredDuringSchedulingIgnoredDuringExecution",
+		"readiness_gate": "readinessGates",
+		"required_during_scheduling_ignored_during_execution": "requiredDuringSchedulingIgnoredDuringExecution",
+		"rule":                       "rules",
+		"subject":                    "subjects",
+		"tls":                        "tls",
+		"to":                         "to",
+		"toleration":                 "tolerations",
+		"topology_spread_constraint": "topologySpreadConstraints",
+		"volume":                     "volumes",
+		"volume_claim_template":      "volumeClaimTemplates",
+		"volume_mount":               "volumeMounts",
+	}
+
+	// terraformObjectBlocks are the list blocks that declare an object rather than an item of a list in the given
+	// parent blocks, e.g. the port of the service of an ingress backend.
+	terraformObjectBlocks = map[string]string{
+		"node_affinity": "required_during_scheduling_ignored_during_execution",
+		"service":       "port",
+	}
+
+	// terraformResourceArguments are the arguments of typed resources that configure Terraform rather than the object.
+	terraformResourceArguments = set.NewFrozenStringSet(
+		"count", "for_each", "depends_on", "provider", "lifecycle", "provisioner", "connection", "timeouts",
+		"wait_for_rollout", "wait_for_load_balancer", "wait_for_completion", "wait_for_default_service_account",
+	)
+)
+
+// evaluateTypedResource converts the body of a typed resource of the kubernetes provider, e.g.
+// kubernetes_deployment_v1, to the object it declares. The names of blocks and attributes are converted from snake
+// case to camel case, while the keys of maps such as labels are kept as they are.
+func (e *terraformEvaluator) evaluateTypedResource(resourceType string, body *hclsyntax.Body) (map[string]interface{}, error) {
+	apiVersion, kind, _ := terraformResourceKind(resourceType)
+	obj, err := e.evaluateTypedBody(body, "", "")
+	if err != nil {
+		return nil, err
+	}
+	obj["apiVersion"] = apiVersion
+	obj["kind"] = kind
+	if kind == "Secret" {
+		// The provider encodes the data of secrets, and takes already encoded data as binary data.
+		if data, found := obj["data"]; found {
+			obj["stringData"] = data
+			delete(obj, "data")
+		}
+		if binaryData, found := obj["binaryData"]; found {
+			obj["data"] = binaryData
+			delete(obj, "binaryData")
+		}
+	}
+	return obj, nil
+}
+
+func (e *terraformEvaluator) evaluateTypedBody(body *hclsyntax.Body, blockType, path string) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	for _, attr := range terraformAttributes(body) {
+		if path == "" && terraformResourceArguments.Contains(attr.Name) {
+			continue
+		}
+		fieldPath := joinFieldPath(path, snakeToCamelCase(attr.Name))
+		value, err := e.evaluate(attr.Expr, fieldPath)
+		if e.omit(err, fieldPath) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj[snakeToCamelCase(attr.Name)] = value
+	}
+
+	blockCounts := make(map[string]int)
+	for _, block := range body.Blocks {
+		blockCounts[block.Type]++
+	}
+	for _, block := range body.Blocks {
+		if path == "" && terraformResourceArguments.Contains(block.Type) {
+			continue
+		}
+		if block.Type == "dynamic" {
+			// The blocks that dynamic blocks generate depend on their for_each argument, which is left out like the
+			// dynamic expressions of attributes.
+			if len(block.Labels) == 1 {
+				e.omit(e.dynamic(hcl.RangeBetween(block.TypeRange, block.LabelRanges[0])), joinFieldPath(path, snakeToCamelCase(block.Labels[0])))
+			}
+			continue
+		}
+		name, isList := terraformListBlocks[block.Type]
+		if !isList || terraformObjectBlocks[blockType] == block.Type {
+			name, isList = snakeToCamelCase(block.Type), blockCounts[block.Type] > 1
+		}
+		fieldPath := joinFieldPath(path, name)
+		value, err := e.evaluateTypedBody(block.Body, block.Type, fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		if !isList {
+			obj[name] = value
+			continue
+		}
+		list, _ := obj[name].([]interface{})
+		obj[name] = append(list, value)
+	}
+	return obj, nil
+}
+
+// terraformAcronymFields maps the snake_case names of arguments that the Kubernetes API spells with an acronym in
+// upper case, e.g. hostPID, to their field names, since the decoding of objects is case-sensitive.
+var terraformAcronymFields = map[string]string{
+	"cluster_ip":       "clusterIP",
+	"cluster_ips":      "clusterIPs",
+	"dataset_uuid":     "datasetUUID",
+	"disk_uri":         "diskURI",
+	"external_ips":     "externalIPs",
+	"host_ip":          "hostIP",
+	"host_ipc":         "hostIPC",
+	"host_pid":         "hostPID",
+	"load_balancer_ip": "loadBalancerIP",
+	"pod_cidr":         "podCIDR",
+	"pod_cidrs":        "podCIDRs",
+	"pod_ip":           "podIP",
+	"pod_ips":          "podIPs",
+	"target_wwns":      "targetWWNs",
+	"volume_id":        "volumeID",
+}
+
+func snakeToCamelCase(name string) string {
+	if field, isAcronym := terraformAcronymFields[name]; isAcronym {
+		return field
+	}
+	words := strings.Split(name, "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
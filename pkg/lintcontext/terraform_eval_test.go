@@ -0,0 +1,155 @@
+package lintcontext
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTerraformConfig = `locals {
+  labels = { app = "web", "app.kubernetes.io/part-of" : "shop" }
+  hosts  = ["a.example.com", "b.example.com"]
+  debug  = false
+}
+
+resource "kubernetes_manifest" "web" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = "web"
+      namespace = "web-${var.environment}"
+      labels    = merge(local.labels, { tier = "frontend" })
+    }
+    data = {
+      replicas = -3
+      debug    = local.debug ? "true" : "false"
+      verbose  = var.verbose ? "true" : "false"
+      hosts    = join(",", [for host in local.hosts : upper(host)])
+      first    = [for host in local.hosts : host if startswith(host, "a")]
+      upper    = [for host in local.hosts : upper(host)][1]
+      list     = "%{for host in local.hosts}${host};%{endfor}"
+      config   = yamlencode({ enabled = true })
+      escaped  = "a\"b $${literal}"
+      script   = <<-EOT
+        echo ${local.labels.app}
+      EOT
+    }
+  }
+}
+
+resource "kubernetes_deployment_v1" "web" {
+  spec {
+    template {
+      spec {
+        container {
+          name = "web"
+
+          dynamic "env" {
+            for_each = var.env
+            content {
+              name  = env.key
+              value = env.value
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+)
+
+func parseTestTerraformConfig(t *testing.T) (*hclsyntax.Body, *terraformEvaluator) {
+	file, diags := hclsyntax.ParseConfig([]byte(testTerraformConfig), "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	body := file.Body.(*hclsyntax.Body)
+	locals := make(map[string]hclsyntax.Expression)
+	for name, attr := range terraformBlocks(body, "locals")[0].Body.Attributes {
+		locals[name] = attr.Expr
+	}
+	return body, newTerraformEvaluator(".", locals, map[string][]byte{"main.tf": []byte(testTerraformConfig)})
+}
+
+func TestTerraformEvaluate(t *testing.T) {
+	body, evaluator := parseTestTerraformConfig(t)
+	resources := terraformBlocks(body, "resource")
+	require.Len(t, resources, 2)
+
+	value, err := evaluator.evaluate(resources[0].Body.Attributes["manifest"].Expr, "")
+	require.NoError(t, err)
+	manifest := value.(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"name": "web",
+		"labels": map[string]interface{}{
+			"app":                       "web",
+			"app.kubernetes.io/part-of": "shop",
+			"tier":                      "frontend",
+		},
+	}, manifest["metadata"])
+	assert.Equal(t, map[string]interface{}{
+		"replicas": -3.0,
+		"debug":    "false",
+		"upper":    "B.EXAMPLE.COM",
+		"list":     "a.example.com;b.example.com;",
+		"config":   "enabled: true\n",
+		"escaped":  `a"b ${literal}`,
+		"script":   "echo web\n",
+	}, manifest["data"])
+
+	omitted := make(map[string]string)
+	for _, field := range evaluator.omitted {
+		omitted[field.path] = field.err.Error()
+	}
+	assert.Equal(t, map[string]string{
+		"metadata.namespace": "`var.environment` on line 13 can only be evaluated by Terraform",
+		"data.verbose":       "`var.verbose` on line 19 can only be evaluated by Terraform",
+		// The functions that manifests are not usually declared with are not evaluated.
+		"data.hosts": "`join(\",\", [for host in local.hosts : upper(host)])` on line 20 can only be evaluated by Terraform",
+		"data.first": "`startswith(host, \"a\")` on line 21 can only be evaluated by Terraform",
+	}, omitted)
+}
+
+func TestTerraformEvaluateTypedResource(t *testing.T) {
+	body, evaluator := parseTestTerraformConfig(t)
+	obj, err := evaluator.evaluateTypedResource("kubernetes_deployment_v1", terraformBlocks(body, "resource")[1].Body)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web"},
+					},
+				},
+			},
+		},
+	}, obj)
+	require.Len(t, evaluator.omitted, 1)
+	assert.Equal(t, "spec.template.spec.containers.env", evaluator.omitted[0].path)
+	assert.Equal(t, "`dynamic \"env\"` on line 40 can only be evaluated by Terraform", evaluator.omitted[0].err.Error())
+}
+
+func TestTerraformEvaluateLocalErrors(t *testing.T) {
+	for src, expectedErr := range map[string]string{
+		`a = local.missing`: "line 1: local value missing is not declared",
+		`a = local.a`:       "line 1: local value a refers to itself",
+		`a = 1 + "a"`:       "line 1: ",
+	} {
+		t.Run(src, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			attr := file.Body.(*hclsyntax.Body).Attributes["a"]
+			evaluator := newTerraformEvaluator(".", map[string]hclsyntax.Expression{"a": attr.Expr}, map[string][]byte{"main.tf": []byte(src)})
+			_, err := evaluator.evaluate(attr.Expr, "a")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), expectedErr)
+		})
+	}
+}
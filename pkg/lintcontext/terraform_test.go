@@ -0,0 +1,139 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	terraformPath = "../../tests/testdata/terraform/main.tf"
+)
+
+func TestTerraformResources(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{Terraform: true}, terraformPath)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 2)
+	lintCtx := lintCtxs[0]
+	assert.Empty(t, lintCtx.InvalidObjects())
+
+	objects := make(map[string]Object)
+	for _, obj := range lintCtx.Objects() {
+		assert.Equal(t, terraformPath, obj.Metadata.FilePath)
+		objects[obj.K8sObject.GetObjectKind().GroupVersionKind().Kind] = obj
+	}
+	require.Len(t, objects, 3)
+
+	configMap := objects["ConfigMap"]
+	assert.Equal(t, 12, configMap.Metadata.LineNumber)
+	assert.Equal(t, map[string]string{"log-level": "info"}, configMap.K8sObject.(*v1.ConfigMap).Data)
+
+	serviceAccount := objects["ServiceAccount"]
+	assert.Equal(t, 16, serviceAccount.Metadata.LineNumber)
+	assert.Equal(t, map[string]string{"app": "web"}, serviceAccount.K8sObject.GetLabels())
+	// The namespace depends on a variable, so it is left out.
+	assert.Empty(t, serviceAccount.K8sObject.GetNamespace())
+
+	deployment := objects["Deployment"]
+	assert.Equal(t, 28, deployment.Metadata.LineNumber)
+	spec := deployment.K8sObject.(*appsV1.Deployment).Spec
+	require.NotNil(t, spec.Replicas)
+	assert.Equal(t, int32(2), *spec.Replicas)
+	assert.Equal(t, map[string]string{"app": "web"}, spec.Selector.MatchLabels)
+	assert.Equal(t, "web", spec.Template.Spec.ServiceAccountName)
+	// host_pid is spelled hostPID in the Kubernetes API.
+	assert.True(t, spec.Template.Spec.HostPID)
+	require.Len(t, spec.Template.Spec.Containers, 1)
+	container := spec.Template.Spec.Containers[0]
+	assert.Equal(t, "nginx:1.23", container.Image)
+	require.Len(t, container.Ports, 1)
+	assert.Equal(t, int32(80), container.Ports[0].ContainerPort)
+	require.NotNil(t, container.SecurityContext.ReadOnlyRootFilesystem)
+	assert.True(t, *container.SecurityContext.ReadOnlyRootFilesystem)
+
+	notes := lintCtx.(NotesContext).Notes()
+	require.Len(t, notes, 3)
+	assert.Equal(t, 22, notes[0].Metadata.LineNumber)
+	assert.Contains(t, notes[0].Message, "metadata.namespace of resource kubernetes_manifest.service_account is left out")
+	// The object is loaded without the field, so the note is about it.
+	assert.Equal(t, serviceAccount.K8sObject, notes[0].Object)
+	assert.Equal(t, 69, notes[1].Metadata.LineNumber)
+	assert.Contains(t, notes[1].Message, "resource kubernetes_manifest.from_variable is not linted, since `var.manifest`")
+	assert.Nil(t, notes[1].Object)
+	assert.Equal(t, 90, notes[2].Metadata.LineNumber)
+	assert.Contains(t, notes[2].Message, "resource helm_release.ingress is not rendered")
+}
+
+func TestTerraformHelmRelease(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{Terraform: true}, terraformPath)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 2)
+	lintCtx := lintCtxs[1]
+	assert.Empty(t, lintCtx.InvalidObjects())
+
+	var deployment *appsV1.Deployment
+	for _, obj := range lintCtx.Objects() {
+		assert.Equal(t, terraformContextName(terraformPath, "app"), obj.Metadata.HelmChart.Release)
+		// autoscaling is disabled by the set block of the release.
+		assert.NotEqual(t, "HorizontalPodAutoscaler", obj.K8sObject.GetObjectKind().GroupVersionKind().Kind)
+		if d, ok := obj.K8sObject.(*appsV1.Deployment); ok {
+			deployment = d
+		}
+	}
+	require.NotNil(t, deployment)
+	assert.Equal(t, "app-mychart", deployment.Name)
+	require.NotNil(t, deployment.Spec.Replicas)
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+}
+
+func TestTerraformOptIn(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{}, filepath.Dir(terraformPath))
+	require.NoError(t, err)
+	for _, lintCtx := range lintCtxs {
+		for _, obj := range lintCtx.Objects() {
+			assert.NotEqual(t, terraformPath, obj.Metadata.FilePath)
+		}
+		assert.Empty(t, lintCtx.InvalidObjects())
+	}
+}
+
+func TestTerraformModule(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "locals.tf"), []byte(`
+locals {
+  name = "web"
+}
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+resource "kubernetes_manifest" "config" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name = local.name
+    }
+  }
+}
+`), 0o600))
+	missingPath := filepath.Join(dir, "missing.tf")
+	require.NoError(t, os.Symlink(filepath.Join(dir, "nonexistent"), missingPath))
+
+	lintCtxs, err := CreateContextsWithOptions(Options{Terraform: true}, dir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	lintCtx := lintCtxs[0]
+
+	// Locals declared in other files of the module are evaluated.
+	require.Len(t, lintCtx.Objects(), 1)
+	assert.Equal(t, "web", lintCtx.Objects()[0].K8sObject.GetName())
+
+	// Files that can't be read are invalid objects rather than aborting the lint.
+	require.Len(t, lintCtx.InvalidObjects(), 1)
+	assert.Equal(t, missingPath, lintCtx.InvalidObjects()[0].Metadata.FilePath)
+	assert.Contains(t, lintCtx.InvalidObjects()[0].LoadErr.Error(), "reading Terraform file")
+}
@@ -0,0 +1,88 @@
+package lintcontext
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+)
+
+// A TransitionContext is a LintContext whose objects are compared to a previous revision of the manifests, for checks
+// that validate the transition from the previous revision to the current one.
+type TransitionContext interface {
+	LintContext
+	// PreviousObject returns the object of the previous revision that the given object replaces, i.e. the one with the
+	// same API group, kind, namespace and name.
+	PreviousObject(obj Object) (Object, bool)
+	// RemovedObjects returns the objects of the previous revision that have no counterpart in the current one.
+	RemovedObjects() []Object
+}
+
+// ObjectKey identifies an object across revisions of the manifests. The API version is not part of the key, since
+// objects keep their identity when they are migrated to another version of their API.
+type ObjectKey struct {
+	Group, Kind, Namespace, Name string
+}
+
+// KeyOf returns the key of the given object.
+func KeyOf(obj k8sutil.Object) ObjectKey {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return ObjectKey{Group: gvk.Group, Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+type transitionContextImpl struct {
+	LintContext
+
+	previousByKey map[ObjectKey]Object
+	removed       []Object
+}
+
+func (t *transitionContextImpl) PreviousObject(obj Object) (Object, bool) {
+	previous, found := t.previousByKey[KeyOf(obj.K8sObject)]
+	return previous, found
+}
+
+func (t *transitionContextImpl) RemovedObjects() []Object {
+	return t.removed
+}
+
+func (t *transitionContextImpl) Notes() []Note {
+	if notesCtx, ok := t.LintContext.(NotesContext); ok {
+		return notesCtx.Notes()
+	}
+	return nil
+}
+
+// WithPreviousRevision returns TransitionContexts that wrap the given lint contexts, and compare their objects to the
+// objects of the previous lint contexts. Objects are paired across all contexts, so that objects which moved to
+// another directory are still paired.
+func WithPreviousRevision(lintCtxs, previousCtxs []LintContext) []LintContext {
+	previousByKey := make(map[ObjectKey]Object)
+	var previousObjects []Object
+	for _, previousCtx := range previousCtxs {
+		for _, obj := range previousCtx.Objects() {
+			previousByKey[KeyOf(obj.K8sObject)] = obj
+			previousObjects = append(previousObjects, obj)
+		}
+	}
+
+	currentKeys := make(map[ObjectKey]struct{})
+	for _, lintCtx := range lintCtxs {
+		for _, obj := range lintCtx.Objects() {
+			currentKeys[KeyOf(obj.K8sObject)] = struct{}{}
+		}
+	}
+	var removed []Object
+	for _, obj := range previousObjects {
+		if _, found := currentKeys[KeyOf(obj.K8sObject)]; !found {
+			removed = append(removed, obj)
+		}
+	}
+
+	transitionCtxs := make([]LintContext, 0, len(lintCtxs))
+	for _, lintCtx := range lintCtxs {
+		transitionCtxs = append(transitionCtxs, &transitionContextImpl{
+			LintContext:   lintCtx,
+			previousByKey: previousByKey,
+			removed:       removed,
+		})
+	}
+	return transitionCtxs
+}
@@ -0,0 +1,81 @@
+package lintcontext
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/vmware-tanzu/carvel-ytt/pkg/cmd/template"
+	"github.com/vmware-tanzu/carvel-ytt/pkg/cmd/ui"
+	"github.com/vmware-tanzu/carvel-ytt/pkg/files"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+// isYttDirectory returns whether the given directory is one of the configured ytt configuration directories.
+func isYttDirectory(yttConfig config.YttConfig, dir string) bool {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for _, yttDir := range yttConfig.Directories {
+		if absYttDir, err := filepath.Abs(yttDir); err == nil && absYttDir == absDir {
+			return true
+		}
+	}
+	return false
+}
+
+// loadObjectsFromYttDirectory renders a ytt configuration directory in-process, with the configured data values
+// files, and loads the resulting documents. The objects are attributed to the templates and the lines that ytt
+// reports their documents to come from, or to the directory if it doesn't know them. Lines within the objects are
+// lines of the rendered documents, so they aren't mapped to the templates.
+func (l *lintContextImpl) loadObjectsFromYttDirectory(dir string, dataValuesFiles []string) {
+	if err := l.renderYttDirectory(dir, dataValuesFiles); err != nil {
+		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: dir}, LoadErr: err})
+	}
+}
+
+func (l *lintContextImpl) renderYttDirectory(dir string, dataValuesFiles []string) error {
+	filesToProcess, err := files.NewSortedFilesFromPaths([]string{dir}, files.SymlinkAllowOpts{})
+	if err != nil {
+		return errors.Wrapf(err, "reading ytt configuration %s", dir)
+	}
+	opts := template.NewOptions()
+	opts.DataValuesFlags.FromFiles = dataValuesFiles
+	out := opts.RunWithFiles(template.Input{Files: filesToProcess}, ui.NewTTY(false))
+	if out.Err != nil {
+		return errors.Wrapf(out.Err, "rendering ytt configuration %s", dir)
+	}
+	if out.DocSet == nil {
+		return nil
+	}
+
+	for _, doc := range out.DocSet.Items {
+		if doc.Value == nil {
+			continue
+		}
+		metadata := ObjectMetadata{FilePath: dir}
+		if pos := doc.Position; pos != nil && pos.IsKnown() {
+			metadata.FilePath = pos.GetFile()
+			if !filepath.IsAbs(metadata.FilePath) {
+				metadata.FilePath = filepath.Join(dir, metadata.FilePath)
+			}
+			metadata.TemplateLineNumber = pos.LineNum()
+		}
+		raw, err := doc.AsYAMLBytes()
+		if err != nil {
+			l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: errors.Wrap(err, "marshaling ytt output")})
+			continue
+		}
+		metadata.Raw = raw
+
+		objs, err := parseObjects(raw, l.customDecoder)
+		if err != nil {
+			l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: err})
+			continue
+		}
+		for _, obj := range objs {
+			l.addObjects(Object{Metadata: metadata, K8sObject: obj})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package lintcontext
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+const (
+	yttDirectory = "../../tests/testdata/ytt/config"
+)
+
+func TestYttDirectory(t *testing.T) {
+	for _, testCase := range []struct {
+		name            string
+		dataValuesFiles []string
+		replicas        int32
+	}{
+		{name: "defaults", replicas: 1},
+		{name: "data values file", dataValuesFiles: []string{"../../tests/testdata/ytt/production-values.yaml"}, replicas: 3},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := Options{Ytt: config.YttConfig{Directories: []string{yttDirectory}, DataValuesFiles: testCase.dataValuesFiles}}
+			lintCtxs, err := CreateContextsWithOptions(options, yttDirectory)
+			require.NoError(t, err)
+			lintCtx := verifyAndGetContext(t, lintCtxs)
+			assert.Empty(t, lintCtx.InvalidObjects())
+			require.Len(t, lintCtx.Objects(), 1)
+
+			obj := lintCtx.Objects()[0]
+			assert.Equal(t, filepath.Join(yttDirectory, "deployment.yaml"), obj.Metadata.FilePath)
+			assert.Equal(t, 2, obj.Metadata.TemplateLineNumber)
+			// Lines within the object are lines of the rendered document, not of the template.
+			assert.Zero(t, obj.Metadata.LineNumber)
+			deployment, ok := obj.K8sObject.(*appsV1.Deployment)
+			require.True(t, ok)
+			assert.Equal(t, "web", deployment.Name)
+			require.NotNil(t, deployment.Spec.Replicas)
+			assert.Equal(t, testCase.replicas, *deployment.Spec.Replicas)
+		})
+	}
+}
+
+func TestYttDirectoryIsOptIn(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{}, yttDirectory)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	lintCtx := lintCtxs[0]
+	// Without rendering, the templates are loaded as they are, and the data values are not an object.
+	assert.NotEmpty(t, lintCtx.InvalidObjects())
+}
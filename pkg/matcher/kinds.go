@@ -0,0 +1,148 @@
+package matcher
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RegexKind matches strings against a (non-anchored) Go regular expression, e.g. "regex:^kube-".
+	// This is also the kind used for terms without a kind prefix.
+	RegexKind = "regex"
+	// GlobKind matches the whole string against a shell-like glob pattern, where "*" matches any
+	// sequence of characters (including "/") and "?" matches a single character, e.g. "glob:quay.io/*".
+	GlobKind = "glob"
+	// ExactKind matches strings equal to one of the comma-separated values, e.g. "exact:NET_RAW,SYS_ADMIN".
+	ExactKind = "exact"
+	// SemverKind matches semantic versions satisfying the given constraint, e.g. "semver:>=1.2.0, <2.0.0".
+	// If the string is a container image reference, its tag is used as the version. Strings that are not
+	// valid semantic versions never match.
+	SemverKind = "semver"
+	// CIDRKind matches IP addresses contained in one of the comma-separated CIDR blocks, e.g. "cidr:10.0.0.0/8".
+	// Strings that are not valid IP addresses never match.
+	CIDRKind = "cidr"
+)
+
+type termConstructor func(pattern string, caseInsensitive bool) (func(string) bool, error)
+
+var (
+	termConstructors = map[string]termConstructor{
+		RegexKind:  compileRegex,
+		GlobKind:   compileGlob,
+		ExactKind:  compileExact,
+		SemverKind: caseSensitiveOnly(SemverKind, compileSemver),
+		CIDRKind:   caseSensitiveOnly(CIDRKind, compileCIDR),
+	}
+)
+
+func caseSensitiveOnly(kind string, f func(pattern string) (func(string) bool, error)) termConstructor {
+	return func(pattern string, caseInsensitive bool) (func(string) bool, error) {
+		if caseInsensitive {
+			return nil, errors.Errorf("flag %q is not supported for kind %s", CaseInsensitiveFlag, kind)
+		}
+		return f(pattern)
+	}
+}
+
+func compileGlob(pattern string, caseInsensitive bool) (func(string) bool, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	inClass := false
+	for _, r := range pattern {
+		switch {
+		case inClass:
+			if r == ']' {
+				inClass = false
+			}
+			sb.WriteRune(r)
+		case r == '*':
+			sb.WriteString(".*")
+		case r == '?':
+			sb.WriteString(".")
+		case r == '[':
+			inClass = true
+			sb.WriteRune(r)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if inClass {
+		return nil, errors.Errorf("unterminated character class in glob %q", pattern)
+	}
+	sb.WriteString("$")
+	return compileRegex(sb.String(), caseInsensitive)
+}
+
+func compileExact(pattern string, caseInsensitive bool) (func(string) bool, error) {
+	values := strings.Split(pattern, ",")
+	for i, value := range values {
+		if caseInsensitive {
+			value = strings.ToLower(value)
+		}
+		values[i] = strings.TrimSpace(value)
+	}
+	return func(s string) bool {
+		if caseInsensitive {
+			s = strings.ToLower(s)
+		}
+		for _, value := range values {
+			if s == value {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func compileSemver(pattern string) (func(string) bool, error) {
+	constraint, err := semver.NewConstraint(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return func(s string) bool {
+		version, err := semver.NewVersion(imageTag(s))
+		if err != nil {
+			return false
+		}
+		return constraint.Check(version)
+	}, nil
+}
+
+// imageTag returns the tag of the given image reference, or the string itself if it has no tag.
+func imageTag(s string) string {
+	if digestIdx := strings.Index(s, "@"); digestIdx != -1 {
+		s = s[:digestIdx]
+	}
+	colonIdx := strings.LastIndex(s, ":")
+	if colonIdx == -1 || strings.Contains(s[colonIdx:], "/") {
+		return s
+	}
+	return s[colonIdx+1:]
+}
+
+func compileCIDR(pattern string) (func(string) bool, error) {
+	var networks []*net.IPNet
+	for _, cidr := range strings.Split(pattern, ",") {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return func(s string) bool {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return false
+		}
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
@@ -2,13 +2,23 @@ package matcher
 
 import (
 	"regexp"
+	"strings"
 
+	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/internal/stringutils"
 )
 
 const (
 	// NegationPrefix is the prefix used for negations.
 	NegationPrefix = "!"
+
+	// OrSeparator separates alternatives in a matcher expression.
+	OrSeparator = " || "
+	// AndSeparator separates conjuncts in a matcher expression. It binds tighter than OrSeparator.
+	AndSeparator = " && "
+
+	// CaseInsensitiveFlag can be appended to a matcher kind (e.g. "glob/i:") to match case-insensitively.
+	CaseInsensitiveFlag = "/i"
 )
 
 func matchAny(_ string) bool {
@@ -16,20 +26,130 @@ func matchAny(_ string) bool {
 }
 
 // ForString constructs a string matcher for the given value.
+//
+// The value is an expression in the following language:
+//   - The empty string matches everything.
+//   - "a || b" matches if either a or b matches, "a && b" matches if both match.
+//     "&&" binds tighter than "||"; the separators must be surrounded by spaces.
+//   - A term may be prefixed with "!" to negate it.
+//   - A term is of the form "<kind>:<pattern>", where kind is one of regex, glob, exact, semver and cidr
+//     (see the corresponding *Kind constants). A term without a known kind is treated as a regex.
+//   - The regex, glob and exact kinds accept a "/i" flag (e.g. "glob/i:") for case-insensitive matching.
 func ForString(value string) (func(string) bool, error) {
+	return parse(value, true)
+}
+
+// Validate checks that the given value is a valid matcher expression, returning a descriptive error
+// if not. If allowNegation is false, expressions using negation are rejected.
+func Validate(value string, allowNegation bool) error {
+	_, err := parse(value, allowNegation)
+	return err
+}
+
+func parse(value string, allowNegation bool) (func(string) bool, error) {
 	if value == "" {
 		return matchAny, nil
 	}
+	alternatives := strings.Split(value, OrSeparator)
+	orMatchers := make([]func(string) bool, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		conjuncts := strings.Split(alternative, AndSeparator)
+		andMatchers := make([]func(string) bool, 0, len(conjuncts))
+		for _, term := range conjuncts {
+			m, err := parseTerm(term, allowNegation)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid matcher %q", term)
+			}
+			andMatchers = append(andMatchers, m)
+		}
+		orMatchers = append(orMatchers, allOf(andMatchers))
+	}
+	if len(orMatchers) == 1 {
+		return orMatchers[0], nil
+	}
+	return anyOf(orMatchers), nil
+}
+
+func allOf(matchers []func(string) bool) func(string) bool {
+	if len(matchers) == 1 {
+		return matchers[0]
+	}
+	return func(s string) bool {
+		for _, m := range matchers {
+			if !m(s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func anyOf(matchers []func(string) bool) func(string) bool {
+	return func(s string) bool {
+		for _, m := range matchers {
+			if m(s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func parseTerm(term string, allowNegation bool) (func(string) bool, error) {
+	if term == "" {
+		return nil, errors.New("empty term")
+	}
 	var negate bool
-	if stringutils.ConsumePrefix(&value, NegationPrefix) {
+	if stringutils.ConsumePrefix(&term, NegationPrefix) {
+		if !allowNegation {
+			return nil, errors.New("negation is not supported for this parameter")
+		}
 		negate = true
 	}
-	re, err := regexp.Compile(value)
+	kind, pattern, caseInsensitive := splitKind(term)
+	constructor := termConstructors[kind]
+	if constructor == nil {
+		// No recognized kind, treat the whole term as a regex for backwards compatibility.
+		constructor = termConstructors[RegexKind]
+		pattern = term
+		caseInsensitive = false
+	}
+	m, err := constructor(pattern, caseInsensitive)
 	if err != nil {
 		return nil, err
 	}
+	if !negate {
+		return m, nil
+	}
 	return func(s string) bool {
-		matched := re.MatchString(s)
-		return matched != negate
+		return !m(s)
 	}, nil
 }
+
+// splitKind splits a term of the form "<kind>[/i]:<pattern>". It returns an empty kind
+// if the term does not start with a known kind.
+func splitKind(term string) (kind, pattern string, caseInsensitive bool) {
+	colonIdx := strings.Index(term, ":")
+	if colonIdx == -1 {
+		return "", term, false
+	}
+	prefix, rest := term[:colonIdx], term[colonIdx+1:]
+	if stringutils.ConsumeSuffix(&prefix, CaseInsensitiveFlag) {
+		caseInsensitive = true
+	}
+	if _, known := termConstructors[prefix]; !known {
+		return "", term, false
+	}
+	return prefix, rest, caseInsensitive
+}
+
+func compileRegex(pattern string, caseInsensitive bool) (func(string) bool, error) {
+	if caseInsensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
+}
@@ -0,0 +1,71 @@
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForString(t *testing.T) {
+	for _, testCase := range []struct {
+		value      string
+		matches    []string
+		notMatches []string
+	}{
+		{value: "", matches: []string{"", "anything"}},
+		{value: "^abc", matches: []string{"abc", "abcd"}, notMatches: []string{"xabc"}},
+		{value: "!^abc", matches: []string{"xabc"}, notMatches: []string{"abc"}},
+		{value: "regex/i:^ABC$", matches: []string{"abc", "aBc"}, notMatches: []string{"abcd"}},
+		{value: "glob:quay.io/*", matches: []string{"quay.io/org/image:v1"}, notMatches: []string{"docker.io/quay.io/x", "quay.io"}},
+		{value: "glob:v1.?", matches: []string{"v1.2"}, notMatches: []string{"v1x2", "v1.22"}},
+		{value: "glob/i:*.EXAMPLE.com", matches: []string{"registry.example.com"}},
+		{value: "glob:[ab]*", matches: []string{"alpha", "beta"}, notMatches: []string{"gamma"}},
+		{value: "exact:NET_RAW, SYS_ADMIN", matches: []string{"NET_RAW", "SYS_ADMIN"}, notMatches: []string{"net_raw", "NET_RAWX"}},
+		{value: "exact/i:net_raw", matches: []string{"NET_RAW"}},
+		{value: "semver:>=1.2.0, <2.0.0", matches: []string{"1.2.0", "nginx:1.9.3", "quay.io:443/org/app:v1.5.0"}, notMatches: []string{"2.0.0", "nginx:latest", "quay.io:443/org/app"}},
+		{value: "cidr:10.0.0.0/8,192.168.0.0/16", matches: []string{"10.1.2.3", "192.168.1.1"}, notMatches: []string{"172.16.0.1", "not-an-ip"}},
+		{value: "glob:quay.io/* || glob:gcr.io/*", matches: []string{"quay.io/a", "gcr.io/b"}, notMatches: []string{"docker.io/c"}},
+		{value: "glob:*-proxy && !exact:istio-proxy", matches: []string{"envoy-proxy"}, notMatches: []string{"istio-proxy", "app"}},
+		{value: "exact:a && exact:b || exact:c", matches: []string{"c"}, notMatches: []string{"a", "b"}},
+		{value: "unknown:abc", matches: []string{"unknown:abc"}, notMatches: []string{"abc"}},
+	} {
+		t.Run(testCase.value, func(t *testing.T) {
+			m, err := ForString(testCase.value)
+			require.NoError(t, err)
+			for _, s := range testCase.matches {
+				assert.True(t, m(s), "expected %q to match", s)
+			}
+			for _, s := range testCase.notMatches {
+				assert.False(t, m(s), "expected %q not to match", s)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, testCase := range []struct {
+		value         string
+		allowNegation bool
+		valid         bool
+	}{
+		{value: "abc", valid: true},
+		{value: "(", valid: false},
+		{value: "!abc", allowNegation: true, valid: true},
+		{value: "!abc", valid: false},
+		{value: "glob:[a", valid: false},
+		{value: "semver:not a constraint", valid: false},
+		{value: "semver/i:>1.0.0", valid: false},
+		{value: "cidr:10.0.0.0/33", valid: false},
+		{value: "abc || ", valid: false},
+	} {
+		t.Run(testCase.value, func(t *testing.T) {
+			err := Validate(testCase.value, testCase.allowNegation)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package run
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -8,9 +9,11 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
 	"golang.stackrox.io/kube-linter/pkg/ignore"
 	"golang.stackrox.io/kube-linter/pkg/instantiatedcheck"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // CheckStatus is enum type.
@@ -23,10 +26,23 @@ const (
 	ChecksFailed CheckStatus = "Failed"
 )
 
+const (
+	// NoteCheck is the check that informational diagnostics about how objects were loaded are reported as, e.g.
+	// for the fields of Terraform resources that can only be evaluated by Terraform.
+	NoteCheck = "not-evaluated"
+
+	// NoteRemediation is the remediation of the diagnostics of NoteCheck.
+	NoteRemediation = "The expression can only be evaluated when the configuration is applied, so what it declares is not linted."
+)
+
 // Result represents the result from a run of the linter.
 type Result struct {
 	Checks  []config.Check
 	Reports []diagnostic.WithContext
+	// Suppressed holds the diagnostics for objects annotated to ignore the check that produced them.
+	Suppressed []diagnostic.WithContext `json:",omitempty"`
+	// Notes holds informational diagnostics about how objects were loaded, which don't fail the run.
+	Notes   []diagnostic.WithContext `json:",omitempty"`
 	Summary Summary
 }
 
@@ -52,25 +68,36 @@ func Run(lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistr
 	}
 
 	for _, lintCtx := range lintCtxs {
+		annotationsByNamespace := namespaceAnnotations(lintCtx)
 		for _, obj := range lintCtx.Objects() {
+			annotationSets := suppressionAnnotationSets(obj, annotationsByNamespace)
 			for _, check := range instantiatedChecks {
 				if !check.Matcher.Matches(obj.K8sObject.GetObjectKind().GroupVersionKind()) {
 					continue
 				}
-				if ignore.ObjectForCheck(obj.K8sObject.GetAnnotations(), check.Spec.Name) {
-					continue
-				}
+				suppression, ignored := ignore.ForCheck(check.Spec.Name, annotationSets...)
 				diagnostics := check.Func(lintCtx, obj)
 				for _, d := range diagnostics {
-					result.Reports = append(result.Reports, diagnostic.WithContext{
+					report := diagnostic.WithContext{
 						Diagnostic:  d,
 						Check:       check.Spec.Name,
 						Remediation: check.Spec.Remediation,
 						Object:      obj,
-					})
+						HelmSource:  helmSource(obj, d),
+					}
+					if report.HelmSource != nil && report.HelmSource.ValuesKey != "" {
+						report.Remediation += fmt.Sprintf(" In the Helm chart, set `%s` in values.yaml.", report.HelmSource.ValuesKey)
+					}
+					if ignored && suppression.AppliesTo(d.Container) {
+						report.Suppression = &diagnostic.Suppression{Justification: suppression.Justification}
+						result.Suppressed = append(result.Suppressed, report)
+						continue
+					}
+					result.Reports = append(result.Reports, report)
 				}
 			}
 		}
+		addNotes(&result, lintCtx, annotationsByNamespace)
 	}
 
 	if len(result.Reports) > 0 {
@@ -83,3 +110,80 @@ func Run(lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistr
 
 	return result, nil
 }
+
+// addNotes adds the notes of the given lint context, if any, to the result as diagnostics of NoteCheck.
+// Notes about an object are suppressed by the annotations that ignore NoteCheck, like the diagnostics of checks.
+func addNotes(result *Result, lintCtx lintcontext.LintContext, annotationsByNamespace map[string]map[string]string) {
+	notesCtx, ok := lintCtx.(lintcontext.NotesContext)
+	if !ok {
+		return
+	}
+	for _, note := range notesCtx.Notes() {
+		report := diagnostic.WithContext{
+			Diagnostic:  diagnostic.Diagnostic{Message: note.Message},
+			Check:       NoteCheck,
+			Remediation: NoteRemediation,
+			Object:      lintcontext.Object{Metadata: note.Metadata, K8sObject: note.Object},
+		}
+		if note.Object == nil {
+			report.Object.K8sObject = &unstructured.Unstructured{}
+			result.Notes = append(result.Notes, report)
+			continue
+		}
+		suppression, ignored := ignore.ForCheck(NoteCheck, suppressionAnnotationSets(report.Object, annotationsByNamespace)...)
+		if ignored && suppression.AppliesTo("") {
+			report.Suppression = &diagnostic.Suppression{Justification: suppression.Justification}
+			result.Suppressed = append(result.Suppressed, report)
+			continue
+		}
+		result.Notes = append(result.Notes, report)
+	}
+}
+
+// namespaceAnnotations returns the annotations of the Namespace objects in the lint context, by namespace name.
+func namespaceAnnotations(lintCtx lintcontext.LintContext) map[string]map[string]string {
+	annotations := make(map[string]map[string]string)
+	for _, obj := range lintCtx.Objects() {
+		gvk := obj.K8sObject.GetObjectKind().GroupVersionKind()
+		if gvk.Group == "" && gvk.Kind == "Namespace" {
+			annotations[obj.K8sObject.GetName()] = obj.K8sObject.GetAnnotations()
+		}
+	}
+	return annotations
+}
+
+// suppressionAnnotationSets returns the annotations that can suppress checks on the given object,
+// from the most to the least specific: the object itself, its pod template, its namespace and
+// the Helm chart it was rendered from.
+func suppressionAnnotationSets(obj lintcontext.Object, annotationsByNamespace map[string]map[string]string) []map[string]string {
+	annotationSets := []map[string]string{obj.K8sObject.GetAnnotations()}
+	if podTemplate, found := extract.PodTemplateSpec(obj.K8sObject); found {
+		annotationSets = append(annotationSets, podTemplate.Annotations)
+	}
+	if namespace := obj.K8sObject.GetNamespace(); namespace != "" {
+		annotationSets = append(annotationSets, annotationsByNamespace[namespace])
+	}
+	if obj.Metadata.HelmChart != nil {
+		annotationSets = append(annotationSets, obj.Metadata.HelmChart.Annotations)
+	}
+	return annotationSets
+}
+
+// helmSource locates the given diagnostic in the template and values of the Helm chart the object was rendered from,
+// or returns nil if the object was not rendered from a Helm chart.
+func helmSource(obj lintcontext.Object, d diagnostic.Diagnostic) *diagnostic.HelmSource {
+	template := obj.Metadata.HelmTemplate
+	if template == nil {
+		return nil
+	}
+	valuesKey := obj.Metadata.HelmValuesKeyOfField(d.FieldPath)
+	if valuesKey == "" {
+		valuesKey = obj.Metadata.HelmValuesKey(d.Line)
+	}
+	return &diagnostic.HelmSource{
+		Template:  template.Path,
+		StartLine: template.StartLine,
+		EndLine:   template.EndLine,
+		ValuesKey: valuesKey,
+	}
+}
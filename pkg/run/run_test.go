@@ -0,0 +1,133 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContainerSuppressions(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+
+	lintCtx := mocks.NewMockContext()
+	lintCtx.AddMockDeployment(t, "app")
+	lintCtx.ModifyDeployment(t, "app", func(deployment *appsV1.Deployment) {
+		deployment.TypeMeta = metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}
+		deployment.Annotations = map[string]string{
+			"ignore-check.kube-linter.io/run-as-non-root":     "containers=istio-proxy Injected by the service mesh",
+			"ignore-check.kube-linter.io/writable-host-mount": "containers=log-shipper",
+		}
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = []v1.Volume{{Name: "logs", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log"}}}}
+		mount := []v1.VolumeMount{{Name: "logs", MountPath: "/logs"}}
+		podSpec.Containers = []v1.Container{
+			{Name: "app", VolumeMounts: mount},
+			{Name: "istio-proxy"},
+			{Name: "log-shipper", VolumeMounts: mount},
+		}
+	})
+
+	result, err := Run([]lintcontext.LintContext{lintCtx}, registry, []string{"run-as-non-root", "writable-host-mount"})
+	require.NoError(t, err)
+
+	containersByCheck := func(reports []diagnostic.WithContext) map[string][]string {
+		containers := make(map[string][]string)
+		for _, report := range reports {
+			containers[report.Check] = append(containers[report.Check], report.Diagnostic.Container)
+		}
+		return containers
+	}
+	assert.Equal(t, map[string][]string{
+		"run-as-non-root":     {"app", "log-shipper"},
+		"writable-host-mount": {"app"},
+	}, containersByCheck(result.Reports))
+	assert.Equal(t, map[string][]string{
+		"run-as-non-root":     {"istio-proxy"},
+		"writable-host-mount": {"log-shipper"},
+	}, containersByCheck(result.Suppressed))
+	for _, report := range result.Suppressed {
+		if report.Check == "run-as-non-root" {
+			assert.Equal(t, "Injected by the service mesh", report.Suppression.Justification)
+		}
+	}
+}
+
+func TestHelmSource(t *testing.T) {
+	obj := lintcontext.Object{Metadata: lintcontext.ObjectMetadata{
+		Raw: []byte("apiVersion: apps/v1\nkind: Deployment\nspec:\n  replicas: 3\n"),
+		HelmTemplate: &lintcontext.HelmTemplateMetadata{
+			Path:      "templates/deployment.yaml",
+			StartLine: 1,
+			EndLine:   40,
+			Values: map[string]string{
+				"spec.replicas":                          "replicaCount",
+				"spec.template.spec.containers[1].image": "sidecar.image",
+			},
+		},
+	}}
+
+	source := helmSource(obj, diagnostic.Diagnostic{FieldPath: "spec.template.spec.containers[1].image", Line: 4})
+	require.NotNil(t, source)
+	assert.Equal(t, diagnostic.HelmSource{Template: "templates/deployment.yaml", StartLine: 1, EndLine: 40, ValuesKey: "sidecar.image"}, *source)
+
+	assert.Equal(t, "replicaCount", helmSource(obj, diagnostic.Diagnostic{Line: 4}).ValuesKey)
+	assert.Empty(t, helmSource(obj, diagnostic.Diagnostic{FieldPath: "spec.template.spec.containers[0].image"}).ValuesKey)
+	assert.Nil(t, helmSource(lintcontext.Object{}, diagnostic.Diagnostic{FieldPath: "spec.replicas"}))
+}
+
+type notesContext struct {
+	*mocks.MockLintContext
+	notes []lintcontext.Note
+}
+
+func (c *notesContext) Notes() []lintcontext.Note {
+	return c.notes
+}
+
+func TestNotes(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+
+	mockCtx := mocks.NewMockContext()
+	mockCtx.AddMockDeployment(t, "app")
+	mockCtx.AddMockDeployment(t, "ignored")
+	mockCtx.ModifyDeployment(t, "ignored", func(deployment *appsV1.Deployment) {
+		deployment.Annotations = map[string]string{"ignore-check.kube-linter.io/" + NoteCheck: "Set by the pipeline"}
+	})
+	objects := make(map[string]lintcontext.Object)
+	for _, obj := range mockCtx.Objects() {
+		objects[obj.K8sObject.GetName()] = obj
+	}
+	metadata := lintcontext.ObjectMetadata{FilePath: "main.tf", LineNumber: 22}
+	lintCtx := &notesContext{MockLintContext: mockCtx, notes: []lintcontext.Note{
+		{Metadata: metadata, Message: "resource kubernetes_manifest.dynamic is not linted"},
+		{Metadata: metadata, Message: "metadata.namespace of resource kubernetes_manifest.app is left out", Object: objects["app"].K8sObject},
+		{Metadata: metadata, Message: "metadata.namespace of resource kubernetes_manifest.ignored is left out", Object: objects["ignored"].K8sObject},
+	}}
+
+	result, err := Run([]lintcontext.LintContext{lintCtx}, registry, []string{"privileged-container"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Reports)
+	// Notes don't fail the run.
+	assert.Equal(t, ChecksPassed, result.Summary.ChecksStatus)
+	require.Len(t, result.Notes, 2)
+	for _, note := range result.Notes {
+		assert.Equal(t, NoteCheck, note.Check)
+		assert.Equal(t, metadata, note.Object.Metadata)
+	}
+	assert.Equal(t, "resource kubernetes_manifest.dynamic is not linted", result.Notes[0].Diagnostic.Message)
+	assert.Equal(t, "app", result.Notes[1].Object.K8sObject.GetName())
+	require.Len(t, result.Suppressed, 1)
+	assert.Equal(t, "Set by the pipeline", result.Suppressed[0].Suppression.Justification)
+}
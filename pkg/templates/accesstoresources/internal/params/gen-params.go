@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	flagRolesNotFoundParamDesc = util.MustParseParameterDesc(`{
 	"Name": "flagRolesNotFound",
@@ -26,8 +28,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "",
 	"XXXStructFieldName": "FlagRolesNotFound",
 	"XXXIsPointer": false
 }
@@ -36,14 +37,13 @@ var (
 	resourcesParamDesc = util.MustParseParameterDesc(`{
 	"Name": "resources",
 	"Type": "array",
-	"Description": "An array of regular expressions specifying resources. e.g. ^secrets$ for secrets and ^*$ for any resources",
+	"Description": "An array of matchers specifying resources. e.g. ^secrets$ for secrets and ^*$ for any resources",
 	"Examples": null,
 	"Enum": null,
 	"SubParameters": null,
 	"ArrayElemType": "string",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": true,
+	"Matcher": "non-negatable",
 	"XXXStructFieldName": "Resources",
 	"XXXIsPointer": false
 }
@@ -52,14 +52,13 @@ var (
 	verbsParamDesc = util.MustParseParameterDesc(`{
 	"Name": "verbs",
 	"Type": "array",
-	"Description": "An array of regular expressions specifying verbs. e.g. ^create$ for create and ^*$ for any k8s verbs",
+	"Description": "An array of matchers specifying verbs. e.g. ^create$ for create and ^*$ for any k8s verbs",
 	"Examples": null,
 	"Enum": null,
 	"SubParameters": null,
 	"ArrayElemType": "string",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": true,
+	"Matcher": "non-negatable",
 	"XXXStructFieldName": "Verbs",
 	"XXXIsPointer": false
 }
@@ -74,6 +73,16 @@ var (
 
 func (p *Params) Validate() error {
 	var validationErrors []string
+	for _, value := range p.Resources {
+		if err := matcher.Validate(value, false); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param resources has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.Verbs {
+		if err := matcher.Validate(value, false); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param verbs has invalid value %q: %v", value, err))
+		}
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
@@ -4,10 +4,10 @@ package params
 type Params struct {
 	// Set to true to flag the roles that are referenced in bindings but not found in the context
 	FlagRolesNotFound bool `json:"flagRolesNotFound"`
-	// An array of regular expressions specifying resources. e.g. ^secrets$ for secrets and ^*$ for any resources
-	// +notnegatable
+	// An array of matchers specifying resources. e.g. ^secrets$ for secrets and ^*$ for any resources
+	// +matcher=non-negatable
 	Resources []string `json:"resources"`
-	// An array of regular expressions specifying verbs. e.g. ^create$ for create and ^*$ for any k8s verbs
-	// +notnegatable
+	// An array of matchers specifying verbs. e.g. ^create$ for create and ^*$ for any k8s verbs
+	// +matcher=non-negatable
 	Verbs []string `json:"verbs"`
 }
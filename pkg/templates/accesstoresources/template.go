@@ -2,7 +2,6 @@ package accesstoresources
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -11,6 +10,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/accesstoresources/internal/params"
@@ -38,31 +38,31 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
-			resourceRegexes := make([]*regexp.Regexp, 0, len(p.Resources))
+			resourceMatchers := make([]func(string) bool, 0, len(p.Resources))
 			for _, res := range p.Resources {
-				r, err := regexp.Compile(res)
+				r, err := matcher.ForString(res)
 				if err != nil {
-					return nil, errors.Wrapf(err, "invalid regex %s", res)
+					return nil, errors.Wrapf(err, "invalid resource %s", res)
 				}
-				resourceRegexes = append(resourceRegexes, r)
+				resourceMatchers = append(resourceMatchers, r)
 			}
-			verbRegexes := make([]*regexp.Regexp, 0, len(p.Verbs))
+			verbMatchers := make([]func(string) bool, 0, len(p.Verbs))
 			for _, verb := range p.Verbs {
-				v, err := regexp.Compile(verb)
+				v, err := matcher.ForString(verb)
 				if err != nil {
-					return nil, errors.Wrapf(err, "invalid regex %s", verb)
+					return nil, errors.Wrapf(err, "invalid verb %s", verb)
 				}
-				verbRegexes = append(verbRegexes, v)
+				verbMatchers = append(verbMatchers, v)
 			}
 			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 				rbinding, ok := object.K8sObject.(*rbacV1.RoleBinding)
 				if ok {
 					namespace := stringutils.OrDefault(rbinding.Namespace, "default")
-					return findRole(rbinding.RoleRef.Name, namespace, lintCtx, resourceRegexes, verbRegexes, p.FlagRolesNotFound)
+					return findRole(rbinding.RoleRef.Name, namespace, lintCtx, resourceMatchers, verbMatchers, p.FlagRolesNotFound)
 				}
 				crbinding, ok := object.K8sObject.(*rbacV1.ClusterRoleBinding)
 				if ok {
-					return findClusterRole(crbinding.RoleRef.Name, lintCtx, resourceRegexes, verbRegexes, p.FlagRolesNotFound)
+					return findClusterRole(crbinding.RoleRef.Name, lintCtx, resourceMatchers, verbMatchers, p.FlagRolesNotFound)
 				}
 				return nil
 			}, nil
@@ -71,27 +71,29 @@ func init() {
 }
 
 // find clusterrole by name, and check if it has access to the specified resource kinds and verbs
-func findClusterRole(name string, lintCtx lintcontext.LintContext, resourceRegexes, verbRegexes []*regexp.Regexp, flag bool) []diagnostic.Diagnostic {
+func findClusterRole(name string, lintCtx lintcontext.LintContext, resourceMatchers, verbMatchers []func(string) bool, flag bool) []diagnostic.Diagnostic {
 	var results []diagnostic.Diagnostic
-	var clusterroles []*rbacV1.ClusterRole
+	var clusterroles []lintcontext.Object
 	for _, object := range lintCtx.Objects() {
-		r, ok := object.K8sObject.(*rbacV1.ClusterRole)
-		if !ok {
-			continue
+		if _, ok := object.K8sObject.(*rbacV1.ClusterRole); ok {
+			clusterroles = append(clusterroles, object)
 		}
-		clusterroles = append(clusterroles, r)
 	}
 
 	var roleExists bool
-	for _, r := range clusterroles {
+	for _, object := range clusterroles {
+		r := object.K8sObject.(*rbacV1.ClusterRole)
 		if r.Name == name && !strings.EqualFold(r.Name, "cluster_admin") {
 			roleExists = true
-			accesses := checkAccess(r.Rules, resourceRegexes, verbRegexes)
+			accesses := checkAccess(r.Rules, resourceMatchers, verbMatchers)
 			if len(accesses) > 0 {
-				results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("binding to %q clusterrole that has %s", r.Name, strings.Join(accesses, ", "))})
+				results = append(results, diagnostic.Diagnostic{
+					Message:        fmt.Sprintf("binding to %q clusterrole that has %s", r.Name, strings.Join(accesses, ", ")),
+					RelatedObjects: []lintcontext.Object{object},
+				})
 			}
 			if r.AggregationRule != nil && len(r.AggregationRule.ClusterRoleSelectors) > 0 {
-				resultsAggregated := findAggregatedAccesses(clusterroles, r.AggregationRule.ClusterRoleSelectors, resourceRegexes, verbRegexes)
+				resultsAggregated := findAggregatedAccesses(clusterroles, r.AggregationRule.ClusterRoleSelectors, resourceMatchers, verbMatchers)
 				results = append(results, resultsAggregated...)
 			}
 		}
@@ -103,18 +105,22 @@ func findClusterRole(name string, lintCtx lintcontext.LintContext, resourceRegex
 }
 
 // find clusterroles by label selectors, and check if they have access to the specified resources and verbs
-func findAggregatedAccesses(clusterroles []*rbacV1.ClusterRole, selectors []metaV1.LabelSelector, resourceRegexes, verbRegexes []*regexp.Regexp) []diagnostic.Diagnostic {
+func findAggregatedAccesses(clusterroles []lintcontext.Object, selectors []metaV1.LabelSelector, resourceMatchers, verbMatchers []func(string) bool) []diagnostic.Diagnostic {
 	var results []diagnostic.Diagnostic
 	for _, s := range selectors {
 		labelSelector, err := metaV1.LabelSelectorAsSelector(&metaV1.LabelSelector{MatchLabels: s.MatchLabels})
 		if err != nil {
 			continue
 		}
-		for _, r := range clusterroles {
+		for _, object := range clusterroles {
+			r := object.K8sObject.(*rbacV1.ClusterRole)
 			if labelSelector.Matches(labels.Set(r.GetLabels())) { // Found the aggregated clusterrole!
-				accesses := checkAccess(r.Rules, resourceRegexes, verbRegexes)
+				accesses := checkAccess(r.Rules, resourceMatchers, verbMatchers)
 				if len(accesses) > 0 {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("binding via aggregationRule to %q clusterrole that has %s", r.Name, strings.Join(accesses, ", "))})
+					results = append(results, diagnostic.Diagnostic{
+						Message:        fmt.Sprintf("binding via aggregationRule to %q clusterrole that has %s", r.Name, strings.Join(accesses, ", ")),
+						RelatedObjects: []lintcontext.Object{object},
+					})
 				}
 			}
 		}
@@ -123,7 +129,7 @@ func findAggregatedAccesses(clusterroles []*rbacV1.ClusterRole, selectors []meta
 }
 
 // find role by name and namespace that has access to the specified resources and verbs
-func findRole(name, namespace string, lintCtx lintcontext.LintContext, resources, verbs []*regexp.Regexp, flag bool) []diagnostic.Diagnostic {
+func findRole(name, namespace string, lintCtx lintcontext.LintContext, resources, verbs []func(string) bool, flag bool) []diagnostic.Diagnostic {
 	results := []diagnostic.Diagnostic{}
 	var roleExists bool
 	for _, object := range lintCtx.Objects() {
@@ -136,7 +142,10 @@ func findRole(name, namespace string, lintCtx lintcontext.LintContext, resources
 			roleExists = true
 			accesses := checkAccess(r.Rules, resources, verbs)
 			if len(accesses) > 0 {
-				results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("binding to %q role that has %s", r.Name, strings.Join(accesses, ", "))})
+				results = append(results, diagnostic.Diagnostic{
+					Message:        fmt.Sprintf("binding to %q role that has %s", r.Name, strings.Join(accesses, ", ")),
+					RelatedObjects: []lintcontext.Object{object},
+				})
 			}
 		}
 	}
@@ -147,12 +156,12 @@ func findRole(name, namespace string, lintCtx lintcontext.LintContext, resources
 }
 
 // find access verbs to a given resource kind
-func checkAccess(rules []rbacV1.PolicyRule, resourceRegex, verbRegex []*regexp.Regexp) []string {
+func checkAccess(rules []rbacV1.PolicyRule, resourceMatchers, verbMatchers []func(string) bool) []string {
 	var accesses []string
 	for _, rule := range rules {
 		var resources []string
 		for _, res := range rule.Resources {
-			if isInList(resourceRegex, res) {
+			if isInList(resourceMatchers, res) {
 				resources = append(resources, res)
 			}
 		}
@@ -161,7 +170,7 @@ func checkAccess(rules []rbacV1.PolicyRule, resourceRegex, verbRegex []*regexp.R
 		}
 		var verbs []string
 		for _, verb := range rule.Verbs {
-			if isInList(verbRegex, verb) {
+			if isInList(verbMatchers, verb) {
 				verbs = append(verbs, verb)
 			}
 		}
@@ -174,9 +183,9 @@ func checkAccess(rules []rbacV1.PolicyRule, resourceRegex, verbRegex []*regexp.R
 }
 
 // isInList returns true if a match found in the list for the given name or a wildcard
-func isInList(regexlist []*regexp.Regexp, name string) bool {
-	for _, regex := range regexlist {
-		if name == "*" || regex.MatchString(name) {
+func isInList(matchers []func(string) bool, name string) bool {
+	for _, m := range matchers {
+		if name == "*" || m(name) {
 			return true
 		}
 	}
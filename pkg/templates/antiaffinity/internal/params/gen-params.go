@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	minReplicasParamDesc = util.MustParseParameterDesc(`{
 	"Name": "minReplicas",
@@ -26,8 +28,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "",
 	"XXXStructFieldName": "MinReplicas",
 	"XXXIsPointer": false
 }
@@ -42,8 +43,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "TopologyKey",
 	"XXXIsPointer": false
 }
@@ -57,6 +57,9 @@ var (
 
 func (p *Params) Validate() error {
 	var validationErrors []string
+	if err := matcher.Validate(p.TopologyKey, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param topologyKey has invalid value %q: %v", p.TopologyKey, err))
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
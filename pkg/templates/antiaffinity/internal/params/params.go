@@ -8,5 +8,6 @@ type Params struct {
 
 	// The topology key that the anti-affinity term should use.
 	// If not specified, it defaults to "kubernetes.io/hostname".
+	// +matcher
 	TopologyKey string
 }
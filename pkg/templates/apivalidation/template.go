@@ -0,0 +1,79 @@
+package apivalidation
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/Masterminds/semver/v3"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/apivalidation/internal/params"
+)
+
+const (
+	templateKey = "api-validation"
+
+	kubernetesModule = "k8s.io/kubernetes"
+)
+
+var (
+	kubernetesVersion = kubernetesVersionFromBuildInfo()
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "API Validation",
+		Key:         templateKey,
+		Description: "Flag core, apps, batch, networking, rbac and policy objects that fail the validation the Kubernetes API server applies on creation",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				errs, err := validate(object.K8sObject)
+				if err != nil {
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("object could not be validated: %v (Kubernetes %s validation)", err, kubernetesVersion),
+					}}
+				}
+				var results []diagnostic.Diagnostic
+				for _, err := range errs {
+					results = append(results, diagnostic.Diagnostic{
+						Message:   fmt.Sprintf("%s (Kubernetes %s validation)", err.Error(), kubernetesVersion),
+						FieldPath: err.Field,
+					})
+				}
+				return results
+			}, nil
+		}),
+	})
+}
+
+// kubernetesVersionFromBuildInfo returns the version of the Kubernetes validation kube-linter is built with,
+// e.g. 1.26.0 for k8s.io/kubernetes v1.26.0.
+func kubernetesVersionFromBuildInfo() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != kubernetesModule {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		version, err := semver.NewVersion(dep.Version)
+		if err != nil {
+			return dep.Version
+		}
+		return fmt.Sprintf("%d.%d.%d", version.Major(), version.Minor(), version.Patch())
+	}
+	return "unknown"
+}
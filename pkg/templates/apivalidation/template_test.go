@@ -0,0 +1,232 @@
+package apivalidation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/apivalidation/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestAPIValidation(t *testing.T) {
+	suite.Run(t, new(APIValidationTestSuite))
+}
+
+type APIValidationTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *APIValidationTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func validationMessage(format string, args ...interface{}) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{Message: fmt.Sprintf("%s (Kubernetes %s validation)", fmt.Sprintf(format, args...), kubernetesVersion)}
+}
+
+func (s *APIValidationTestSuite) addDeployment(name string, f func(deployment *appsV1.Deployment)) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Selector = &metaV1.LabelSelector{MatchLabels: map[string]string{"app": name}}
+		deployment.Spec.Template.Labels = map[string]string{"app": name}
+		deployment.Spec.Template.Spec.Containers = []coreV1.Container{{Name: "app", Image: "app:1.0"}}
+		f(deployment)
+	})
+}
+
+func (s *APIValidationTestSuite) TestDeployments() {
+	const (
+		valid           = "valid"
+		invalid         = "invalid"
+		invalidStrategy = "invalid-strategy"
+	)
+	s.addDeployment(valid, func(deployment *appsV1.Deployment) {})
+	s.addDeployment(invalid, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Replicas = new(int32)
+		*deployment.Spec.Replicas = -1
+		deployment.Spec.Template.Labels = map[string]string{"app": "other"}
+		deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers,
+			coreV1.Container{Name: "app", ImagePullPolicy: "Sometimes"})
+	})
+	s.addDeployment(invalidStrategy, func(deployment *appsV1.Deployment) {
+		zero := intstr.FromInt(0)
+		deployment.Spec.Strategy = appsV1.DeploymentStrategy{
+			Type:          appsV1.RecreateDeploymentStrategyType,
+			RollingUpdate: &appsV1.RollingUpdateDeployment{MaxUnavailable: &zero, MaxSurge: &zero},
+		}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				valid: {},
+				invalid: {
+					validationMessage("spec.replicas: Invalid value: -1: must be greater than or equal to 0"),
+					validationMessage("spec.template.metadata.labels: Invalid value: map[string]string{\"app\":\"other\"}: `selector` does not match template `labels`"),
+					validationMessage("spec.template.spec.containers[1].name: Duplicate value: \"app\""),
+					validationMessage("spec.template.spec.containers[1].image: Required value"),
+					validationMessage("spec.template.spec.containers[1].imagePullPolicy: Unsupported value: \"Sometimes\": supported values: \"Always\", \"IfNotPresent\", \"Never\""),
+				},
+				invalidStrategy: {
+					validationMessage("spec.strategy.rollingUpdate: Forbidden: may not be specified when strategy `type` is 'Recreate'"),
+				},
+			},
+		},
+	})
+}
+
+func (s *APIValidationTestSuite) TestServices() {
+	const (
+		valid   = "valid"
+		invalid = "Invalid"
+	)
+	s.ctx.AddMockService(s.T(), valid)
+	s.ctx.ModifyService(s.T(), valid, func(service *coreV1.Service) {
+		service.Spec.Ports = []coreV1.ServicePort{{Port: 80, TargetPort: intstr.FromString("http")}}
+	})
+	s.ctx.AddMockService(s.T(), invalid)
+	s.ctx.ModifyService(s.T(), invalid, func(service *coreV1.Service) {
+		service.Spec.Ports = []coreV1.ServicePort{{Port: 80, NodePort: 30080}}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				valid: {},
+				invalid: {
+					validationMessage("metadata.name: Invalid value: \"Invalid\": a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')"),
+					validationMessage("spec.ports[0].nodePort: Forbidden: may not be used when `type` is 'ClusterIP'"),
+				},
+			},
+		},
+	})
+}
+
+func (s *APIValidationTestSuite) TestRBAC() {
+	const (
+		role        = "role"
+		roleBinding = "role-binding"
+	)
+	s.ctx.AddMockRole(s.T(), role, "default")
+	s.ctx.ModifyRole(s.T(), role, func(role *rbacV1.Role) {
+		role.Rules = []rbacV1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+			{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}},
+		}
+	})
+	s.ctx.AddMockRoleBinding(s.T(), roleBinding, "default")
+	s.ctx.ModifyRoleBinding(s.T(), roleBinding, func(roleBinding *rbacV1.RoleBinding) {
+		roleBinding.RoleRef = rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "Role", Name: role}
+		roleBinding.Subjects = []rbacV1.Subject{
+			{Kind: rbacV1.ServiceAccountKind, Name: "app"},
+			// The API group of User subjects is defaulted.
+			{Kind: rbacV1.UserKind, Name: "jane"},
+			{Kind: "Robot", Name: "r2d2"},
+		}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				role: {
+					validationMessage("rules[1].nonResourceURLs: Invalid value: []string{\"/healthz\"}: namespaced rules cannot apply to non-resource URLs"),
+				},
+				roleBinding: {
+					validationMessage("subjects[2].kind: Unsupported value: \"Robot\": supported values: \"ServiceAccount\", \"User\", \"Group\""),
+				},
+			},
+		},
+	})
+}
+
+func (s *APIValidationTestSuite) TestNetworkPolicies() {
+	const networkPolicy = "network-policy"
+	s.ctx.AddMockNetworkPolicy(s.T(), networkPolicy)
+	s.ctx.ModifyNetworkPolicy(s.T(), networkPolicy, func(networkPolicy *networkingV1.NetworkPolicy) {
+		port := intstr.FromString("http")
+		endPort := int32(8080)
+		networkPolicy.Spec.Ingress = []networkingV1.NetworkPolicyIngressRule{{
+			Ports: []networkingV1.NetworkPolicyPort{{Port: &port, EndPort: &endPort}},
+			From: []networkingV1.NetworkPolicyPeer{
+				{IPBlock: &networkingV1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.1.0.0/24"}}},
+			},
+		}}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				networkPolicy: {
+					validationMessage("spec.ingress[0].ports[0].endPort: Invalid value: 8080: may not be specified when `port` is non-numeric"),
+					validationMessage("spec.ingress[0].from[0].ipBlock.except[0]: Invalid value: \"10.1.0.0/24\": must be a strict subset of `cidr`"),
+				},
+			},
+		},
+	})
+}
+
+func (s *APIValidationTestSuite) TestCreate() {
+	const (
+		job                = "job"
+		service            = "service"
+		clusterRoleBinding = "cluster-role-binding"
+		pod                = "pod"
+	)
+	// The selector of the Job is generated, and the protocol of the Service port is defaulted, by the API server.
+	s.ctx.AddObject(job, &batchV1.Job{
+		ObjectMeta: metaV1.ObjectMeta{Name: job},
+		Spec: batchV1.JobSpec{Template: coreV1.PodTemplateSpec{Spec: coreV1.PodSpec{
+			Containers:    []coreV1.Container{{Name: "app", Image: "app:1.0"}},
+			RestartPolicy: coreV1.RestartPolicyNever,
+		}}},
+	})
+	s.ctx.AddObject(service, &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": service},
+		"spec": map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": int64(80)}},
+		},
+	}})
+	// The namespace of cluster-scoped objects is cleared by the API server.
+	s.ctx.AddObject(clusterRoleBinding, &rbacV1.ClusterRoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{Name: clusterRoleBinding, Namespace: "default"},
+		RoleRef:    rbacV1.RoleRef{APIGroup: rbacV1.GroupName, Kind: "ClusterRole", Name: "view"},
+	})
+	s.ctx.AddObject(pod, &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: pod},
+		Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "app"}}},
+	})
+
+	checkFunc, err := s.Template.Instantiate(params.Params{})
+	s.Require().NoError(err)
+	diagnostics := make(map[string][]diagnostic.Diagnostic)
+	for _, obj := range s.ctx.Objects() {
+		diagnostics[obj.GetK8sObjectName().Name] = checkFunc(s.ctx, obj)
+	}
+	s.Empty(diagnostics[job])
+	s.Empty(diagnostics[service])
+	s.Empty(diagnostics[clusterRoleBinding])
+	s.Equal([]diagnostic.Diagnostic{{
+		Message:   validationMessage("spec.containers[0].image: Required value").Message,
+		FieldPath: "spec.containers[0].image",
+	}}, diagnostics[pod])
+}
@@ -0,0 +1,230 @@
+package apivalidation
+
+import (
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	podUtil "k8s.io/kubernetes/pkg/api/pod"
+	"k8s.io/kubernetes/pkg/apis/apps"
+	appsValidation "k8s.io/kubernetes/pkg/apis/apps/validation"
+	"k8s.io/kubernetes/pkg/apis/batch"
+	batchValidation "k8s.io/kubernetes/pkg/apis/batch/validation"
+	"k8s.io/kubernetes/pkg/apis/core"
+	coreValidation "k8s.io/kubernetes/pkg/apis/core/validation"
+	"k8s.io/kubernetes/pkg/apis/networking"
+	networkingValidation "k8s.io/kubernetes/pkg/apis/networking/validation"
+	"k8s.io/kubernetes/pkg/apis/policy"
+	policyValidation "k8s.io/kubernetes/pkg/apis/policy/validation"
+	"k8s.io/kubernetes/pkg/apis/rbac"
+	rbacValidation "k8s.io/kubernetes/pkg/apis/rbac/validation"
+	"k8s.io/kubernetes/pkg/capabilities"
+
+	// The install packages register the versioned types of the API groups, with their defaulting and their
+	// conversion to the internal types that the validation applies to.
+	appsInstall "k8s.io/kubernetes/pkg/apis/apps/install"
+	batchInstall "k8s.io/kubernetes/pkg/apis/batch/install"
+	coreInstall "k8s.io/kubernetes/pkg/apis/core/install"
+	networkingInstall "k8s.io/kubernetes/pkg/apis/networking/install"
+	policyInstall "k8s.io/kubernetes/pkg/apis/policy/install"
+	rbacInstall "k8s.io/kubernetes/pkg/apis/rbac/install"
+)
+
+const (
+	// placeholderUID stands in for the UID the API server assigns on creation, which the selector it generates
+	// for Jobs is based on.
+	placeholderUID = "00000000-0000-0000-0000-000000000000"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	coreInstall.Install(scheme)
+	appsInstall.Install(scheme)
+	batchInstall.Install(scheme)
+	networkingInstall.Install(scheme)
+	rbacInstall.Install(scheme)
+	policyInstall.Install(scheme)
+
+	// Privileged containers are allowed by the API server if it is started with --allow-privileged, which all
+	// common distributions do. Whether they should be used is up to the privileged-container check.
+	capabilities.Initialize(capabilities.Capabilities{AllowPrivileged: true})
+}
+
+// validate applies the validation of the Kubernetes API server on creation to the given object, after converting
+// it to the internal version of its API group and defaulting it like the API server does. Objects of other API
+// groups, or of versions that are no longer served, are not validated.
+func validate(obj k8sutil.Object) (field.ErrorList, error) {
+	versioned, err := toVersioned(obj)
+	if versioned == nil || err != nil {
+		return nil, err
+	}
+	scheme.Default(versioned)
+	gvk := versioned.GetObjectKind().GroupVersionKind()
+	if gvks, _, err := scheme.ObjectKinds(versioned); err == nil {
+		gvk = gvks[0]
+	}
+	internal, err := scheme.ConvertToVersion(versioned, schema.GroupVersion{Group: gvk.Group, Version: runtime.APIVersionInternal})
+	if err != nil {
+		return nil, errors.Wrapf(err, "converting %s to the internal version", gvk)
+	}
+	return validateInternal(internal), nil
+}
+
+// toVersioned returns a copy of the given object as a registered versioned type, or nil if its kind is not
+// registered.
+func toVersioned(obj k8sutil.Object) (runtime.Object, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		if _, _, err := scheme.ObjectKinds(obj); err != nil {
+			return nil, nil
+		}
+		return obj.DeepCopyObject(), nil
+	}
+	typed, err := scheme.New(u.GroupVersionKind())
+	if err != nil {
+		return nil, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+		return nil, errors.Wrapf(err, "decoding %s", u.GroupVersionKind())
+	}
+	typed.GetObjectKind().SetGroupVersionKind(u.GroupVersionKind())
+	return typed, nil
+}
+
+// validateInternal calls the validation that the API server applies on creation to objects of the given internal
+// type, with the options it derives from a new object.
+func validateInternal(obj runtime.Object) field.ErrorList {
+	switch obj := obj.(type) {
+	case *core.Pod:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidatePodCreate(obj, podUtil.GetValidationOptionsFromPodSpecAndMeta(&obj.Spec, nil, &obj.ObjectMeta, nil))
+	case *core.PodTemplate:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidatePodTemplate(obj, podUtil.GetValidationOptionsFromPodTemplate(&obj.Template, nil))
+	case *core.ReplicationController:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateReplicationController(obj, podUtil.GetValidationOptionsFromPodTemplate(obj.Spec.Template, nil))
+	case *core.Service:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateServiceCreate(obj)
+	case *core.Endpoints:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateEndpointsCreate(obj)
+	case *core.ConfigMap:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateConfigMap(obj)
+	case *core.Secret:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateSecret(obj)
+	case *core.ServiceAccount:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateServiceAccount(obj)
+	case *core.PersistentVolumeClaim:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidatePersistentVolumeClaim(obj, coreValidation.ValidationOptionsForPersistentVolumeClaim(obj, nil))
+	case *core.PersistentVolume:
+		clearNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidatePersistentVolume(obj, coreValidation.ValidationOptionsForPersistentVolume(obj, nil))
+	case *core.LimitRange:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateLimitRange(obj)
+	case *core.ResourceQuota:
+		setNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateResourceQuota(obj)
+	case *core.Namespace:
+		clearNamespace(&obj.ObjectMeta)
+		return coreValidation.ValidateNamespace(obj)
+	case *apps.Deployment:
+		setNamespace(&obj.ObjectMeta)
+		return appsValidation.ValidateDeployment(obj, podUtil.GetValidationOptionsFromPodTemplate(&obj.Spec.Template, nil))
+	case *apps.StatefulSet:
+		setNamespace(&obj.ObjectMeta)
+		return appsValidation.ValidateStatefulSet(obj, podUtil.GetValidationOptionsFromPodTemplate(&obj.Spec.Template, nil))
+	case *apps.DaemonSet:
+		setNamespace(&obj.ObjectMeta)
+		return appsValidation.ValidateDaemonSet(obj, podUtil.GetValidationOptionsFromPodTemplate(&obj.Spec.Template, nil))
+	case *apps.ReplicaSet:
+		setNamespace(&obj.ObjectMeta)
+		return appsValidation.ValidateReplicaSet(obj, podUtil.GetValidationOptionsFromPodTemplate(&obj.Spec.Template, nil))
+	case *batch.Job:
+		setNamespace(&obj.ObjectMeta)
+		if obj.Spec.ManualSelector == nil || !*obj.Spec.ManualSelector {
+			generateJobSelector(obj)
+		}
+		return batchValidation.ValidateJob(obj, batchValidation.JobValidationOptions{
+			PodValidationOptions:    podUtil.GetValidationOptionsFromPodTemplate(&obj.Spec.Template, nil),
+			AllowTrackingAnnotation: true,
+		})
+	case *batch.CronJob:
+		setNamespace(&obj.ObjectMeta)
+		return batchValidation.ValidateCronJobCreate(obj, podUtil.GetValidationOptionsFromPodTemplate(&obj.Spec.JobTemplate.Spec.Template, nil))
+	case *networking.NetworkPolicy:
+		setNamespace(&obj.ObjectMeta)
+		return networkingValidation.ValidateNetworkPolicy(obj, networkingValidation.ValidationOptionsForNetworking(obj, nil))
+	case *networking.Ingress:
+		setNamespace(&obj.ObjectMeta)
+		return networkingValidation.ValidateIngressCreate(obj)
+	case *networking.IngressClass:
+		clearNamespace(&obj.ObjectMeta)
+		return networkingValidation.ValidateIngressClass(obj)
+	case *rbac.Role:
+		setNamespace(&obj.ObjectMeta)
+		return rbacValidation.ValidateRole(obj)
+	case *rbac.ClusterRole:
+		clearNamespace(&obj.ObjectMeta)
+		return rbacValidation.ValidateClusterRole(obj, rbacValidation.ClusterRoleValidationOptions{})
+	case *rbac.RoleBinding:
+		setNamespace(&obj.ObjectMeta)
+		return rbacValidation.ValidateRoleBinding(obj)
+	case *rbac.ClusterRoleBinding:
+		clearNamespace(&obj.ObjectMeta)
+		return rbacValidation.ValidateClusterRoleBinding(obj)
+	case *policy.PodDisruptionBudget:
+		setNamespace(&obj.ObjectMeta)
+		return policyValidation.ValidatePodDisruptionBudget(obj, policyValidation.PodDisruptionBudgetValidationOptions{})
+	}
+	return nil
+}
+
+// setNamespace sets the namespace of a namespaced object that has none, since it is set when the object is applied.
+func setNamespace(meta *metaV1.ObjectMeta) {
+	if meta.Namespace == "" {
+		meta.Namespace = metaV1.NamespaceDefault
+	}
+}
+
+// clearNamespace clears the namespace of a cluster-scoped object, like the API server does on creation.
+func clearNamespace(meta *metaV1.ObjectMeta) {
+	meta.Namespace = metaV1.NamespaceNone
+}
+
+// generateJobSelector generates the selector of a Job and the matching labels of its pod template, like the API
+// server does for Jobs that don't set spec.manualSelector.
+func generateJobSelector(job *batch.Job) {
+	if job.UID == "" {
+		job.UID = placeholderUID
+	}
+	if job.Spec.Template.Labels == nil {
+		job.Spec.Template.Labels = make(map[string]string)
+	}
+	for key, value := range map[string]string{"job-name": job.Name, "controller-uid": string(job.UID)} {
+		if _, found := job.Spec.Template.Labels[key]; !found {
+			job.Spec.Template.Labels[key] = value
+		}
+	}
+	if job.Spec.Selector == nil {
+		job.Spec.Selector = &metaV1.LabelSelector{}
+	}
+	if job.Spec.Selector.MatchLabels == nil {
+		job.Spec.Selector.MatchLabels = make(map[string]string)
+	}
+	if _, found := job.Spec.Selector.MatchLabels["controller-uid"]; !found {
+		job.Spec.Selector.MatchLabels["controller-uid"] = string(job.UID)
+	}
+}
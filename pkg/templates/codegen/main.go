@@ -47,6 +47,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -54,6 +55,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 {{- range . }}
 
@@ -83,6 +85,19 @@ func (p *Params) Validate() error {
 		validationErrors = append(validationErrors, "required param {{.ParamDesc.Name}} not found")
 	}
 	{{- end }}
+	{{- if .ParamDesc.Matcher }}
+	{{- if eq .ParamDesc.Type "array" }}
+	for _, value := range p.{{ .ParamDesc.XXXStructFieldName }} {
+		if err := matcher.Validate(value, {{ ne .ParamDesc.Matcher "non-negatable" }}); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} has invalid value %q: %v", value, err))
+		}
+	}
+	{{- else }}
+	if err := matcher.Validate(p.{{ .ParamDesc.XXXStructFieldName }}, {{ ne .ParamDesc.Matcher "non-negatable" }}); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param {{ .ParamDesc.Name }} has invalid value %q: %v", p.{{ .ParamDesc.XXXStructFieldName }}, err))
+	}
+	{{- end }}
+	{{- end }}
 	{{- if .ParamDesc.Enum }}
 	{{- if eq .ParamDesc.Type "array" }}
 	for _, value := range p.{{ .ParamDesc.XXXStructFieldName }} {
@@ -192,11 +207,43 @@ func setBoolBasedOnPresenceOfTag(valToSet *bool, tag string, extractedTags map[s
 	return nil
 }
 
+func setMatcherCapability(desc *check.ParameterDesc, extractedTags map[string][]string) error {
+	val, exists := extractedTags["matcher"]
+	if !exists {
+		return nil
+	}
+	if len(val) != 1 {
+		return errors.Errorf("invalid value for tag matcher: %v; tag must be specified at most once", val)
+	}
+	if desc.Type != check.StringType && !(desc.Type == check.ArrayType && desc.ArrayElemType == check.StringType) {
+		return errors.Errorf("tag matcher is only supported for strings and arrays of strings, not %s", desc.Type)
+	}
+	switch capability := check.MatcherCapability(val[0]); capability {
+	case "":
+		desc.Matcher = check.FullMatcher
+	case check.FullMatcher, check.NonNegatableMatcher:
+		desc.Matcher = capability
+	default:
+		return errors.Errorf("invalid value for tag matcher: %q", capability)
+	}
+	return nil
+}
+
 func constructParameterDescsFromStruct(typeSpec *types.Type) ([]check.ParameterDesc, error) {
 	var paramDescs []check.ParameterDesc
 	for _, member := range typeSpec.Members {
 		if member.Embedded {
-			return nil, errors.Errorf("cannot handle embedded member %s in %+v", member.Name, typeSpec)
+			// The parameters of embedded structs, such as the ones shared between templates, are promoted
+			// to the embedding struct, just like their fields are in Go.
+			if member.Type.Kind != types.Struct {
+				return nil, errors.Errorf("cannot handle embedded member %s of kind %s in %+v", member.Name, member.Type.Kind, typeSpec)
+			}
+			embeddedParams, err := constructParameterDescsFromStruct(member.Type)
+			if err != nil {
+				return nil, errors.Wrapf(err, "handling embedded member %v", member.Name)
+			}
+			paramDescs = append(paramDescs, embeddedParams...)
+			continue
 		}
 
 		desc := check.ParameterDesc{
@@ -241,11 +288,8 @@ func constructParameterDescsFromStruct(typeSpec *types.Type) ([]check.ParameterD
 		if err := setBoolBasedOnPresenceOfTag(&desc.Required, "required", extractedTags); err != nil {
 			return nil, err
 		}
-		if err := setBoolBasedOnPresenceOfTag(&desc.NoRegex, "noregex", extractedTags); err != nil {
-			return nil, err
-		}
-		if err := setBoolBasedOnPresenceOfTag(&desc.NotNegatable, "notnegatable", extractedTags); err != nil {
-			return nil, err
+		if err := setMatcherCapability(&desc, extractedTags); err != nil {
+			return nil, errors.Wrapf(err, "handling field %v", member.Name)
 		}
 		paramDescs = append(paramDescs, desc)
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	forbiddenCapabilitiesParamDesc = util.MustParseParameterDesc(`{
 	"Name": "forbiddenCapabilities",
@@ -26,8 +28,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "string",
 	"Required": false,
-	"NoRegex": true,
-	"NotNegatable": true,
+	"Matcher": "non-negatable",
 	"XXXStructFieldName": "ForbiddenCapabilities",
 	"XXXIsPointer": false
 }
@@ -42,21 +43,152 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "string",
 	"Required": false,
-	"NoRegex": true,
-	"NotNegatable": true,
+	"Matcher": "non-negatable",
 	"XXXStructFieldName": "Exceptions",
 	"XXXIsPointer": false
 }
+`)
+
+	includeContainersParamDesc = util.MustParseParameterDesc(`{
+	"Name": "includeContainers",
+	"Type": "array",
+	"Description": "list of matchers for the names of containers to check. If empty, containers are checked regardless of their name.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"Matcher": "full",
+	"XXXStructFieldName": "IncludeContainers",
+	"XXXIsPointer": false
+}
+`)
+
+	excludeContainersParamDesc = util.MustParseParameterDesc(`{
+	"Name": "excludeContainers",
+	"Type": "array",
+	"Description": "list of matchers for the names of containers not to check.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"Matcher": "full",
+	"XXXStructFieldName": "ExcludeContainers",
+	"XXXIsPointer": false
+}
+`)
+
+	includeImagesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "includeImages",
+	"Type": "array",
+	"Description": "list of matchers for the images of containers to check. If empty, containers are checked regardless of their image.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"Matcher": "full",
+	"XXXStructFieldName": "IncludeImages",
+	"XXXIsPointer": false
+}
+`)
+
+	excludeImagesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "excludeImages",
+	"Type": "array",
+	"Description": "list of matchers for the images of containers not to check.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"Matcher": "full",
+	"XXXStructFieldName": "ExcludeImages",
+	"XXXIsPointer": false
+}
+`)
+
+	containerTypesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "containerTypes",
+	"Type": "array",
+	"Description": "The types of containers to check. If empty, all the containers the template looks at are checked.",
+	"Examples": null,
+	"Enum": [
+		"init",
+		"native-sidecar",
+		"regular",
+		"ephemeral"
+	],
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "ContainerTypes",
+	"XXXIsPointer": false
+}
 `)
 
 	ParamDescs = []check.ParameterDesc{
 		forbiddenCapabilitiesParamDesc,
 		exceptionsParamDesc,
+		includeContainersParamDesc,
+		excludeContainersParamDesc,
+		includeImagesParamDesc,
+		excludeImagesParamDesc,
+		containerTypesParamDesc,
 	}
 )
 
 func (p *Params) Validate() error {
 	var validationErrors []string
+	for _, value := range p.ForbiddenCapabilities {
+		if err := matcher.Validate(value, false); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param forbiddenCapabilities has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.Exceptions {
+		if err := matcher.Validate(value, false); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param exceptions has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.IncludeContainers {
+		if err := matcher.Validate(value, true); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param includeContainers has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.ExcludeContainers {
+		if err := matcher.Validate(value, true); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param excludeContainers has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.IncludeImages {
+		if err := matcher.Validate(value, true); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param includeImages has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.ExcludeImages {
+		if err := matcher.Validate(value, true); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param excludeImages has invalid value %q: %v", value, err))
+		}
+	}
+	for _, value := range p.ContainerTypes {
+		var found bool
+		for _, allowedValue := range []string{
+			"init",
+			"native-sidecar",
+			"regular",
+			"ephemeral",
+		}{
+			if value == allowedValue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			validationErrors = append(validationErrors, fmt.Sprintf("param containerTypes has invalid value %q, must be one of [init native-sidecar regular ephemeral]", p.ContainerTypes))
+		}
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
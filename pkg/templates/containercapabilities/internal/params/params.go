@@ -1,16 +1,20 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 
 	// List of capabilities that needs to be removed from containers.
-	// +noregex
-	// +notnegatable
+	// +matcher=non-negatable
 	ForbiddenCapabilities []string `json:"forbiddenCapabilities"`
 
 	// List of capabilities that are exceptions to the above list. This should only be filled
 	// when the above contains "all", and is used to forgive capabilities in ADD list.
-	// +noregex
-	// +notnegatable
+	// +matcher=non-negatable
 	Exceptions []string `json:"exceptions"`
+
+	util.ContainerFilterParams
 }
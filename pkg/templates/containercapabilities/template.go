@@ -60,6 +60,7 @@ func checkCapabilityDropList(
 						dropListWithAllDiagMsgFmt,
 						containerName,
 						scCaps.Drop),
+					FieldPath: "securityContext.capabilities.drop",
 				})
 	}
 
@@ -84,6 +85,7 @@ func checkCapabilityDropList(
 							containerName,
 							scCaps.Drop,
 							paramCap),
+						FieldPath: "securityContext.capabilities.drop",
 					})
 		}
 	}
@@ -99,7 +101,7 @@ func checkCapabilityAddList(
 ) {
 	if forbidAll {
 		// User has forbidden all capabilities
-		for _, scCap := range scCaps.Add {
+		for i, scCap := range scCaps.Add {
 			var excluded bool
 			for _, exceptionCapMatcher := range exceptionCapMatchers {
 				if exceptionCapMatcher(string(scCap)) {
@@ -117,6 +119,7 @@ func checkCapabilityAddList(
 								addListWithAllDiagMsgFmt,
 								containerName,
 								scCap),
+							FieldPath: fmt.Sprintf("securityContext.capabilities.add[%d]", i),
 						})
 			}
 		}
@@ -126,7 +129,7 @@ func checkCapabilityAddList(
 
 	// Any capability from scCaps should not match with any from paramCaps
 	for _, paramCapMatcher := range paramCapMatchers {
-		for _, scCap := range scCaps.Add {
+		for i, scCap := range scCaps.Add {
 			// User can specify to add "all" under containers as well.
 			if paramCapMatcher(string(scCap)) || literalReservedCapabilitiesAllMatcher(string(scCap)) {
 				// A capability from ADD list matched with a cap from forbidden capabilities list.
@@ -138,6 +141,7 @@ func checkCapabilityAddList(
 								addListDiagMsgFmt,
 								containerName,
 								scCap),
+							FieldPath: fmt.Sprintf("securityContext.capabilities.add[%d]", i),
 						})
 			}
 		}
@@ -226,7 +230,11 @@ func init() {
 				}
 			}
 
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var result []diagnostic.Diagnostic
 				sc := container.SecurityContext
 				if sc != nil && sc.Capabilities != nil {
@@ -1,5 +1,9 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 
@@ -19,4 +23,6 @@ type Params struct {
 	// a number of milli-cores.
 	// If not specified, it is treated as "no upper bound".
 	UpperBoundMillis *int `json:"upperBoundMillis"`
+
+	util.ContainerFilterParams
 }
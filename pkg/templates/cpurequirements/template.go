@@ -14,10 +14,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
-func process(results *[]diagnostic.Diagnostic, containerName, requirementsType string, quantity *resource.Quantity, lowerBound int, upperBound *int) {
-	if util.ValueInRange(int(quantity.MilliValue()), lowerBound, upperBound) {
+func process(results *[]diagnostic.Diagnostic, containerName, requirementsType, fieldPath string, quantity *resource.Quantity, lowerBound int, upperBound *int) {
+	if util.CPUInRange(quantity, lowerBound, upperBound) {
 		*results = append(*results, diagnostic.Diagnostic{
-			Message: fmt.Sprintf("container %q has cpu %s %s", containerName, requirementsType, quantity),
+			Message:   fmt.Sprintf("container %q has cpu %s %s", containerName, requirementsType, quantity),
+			FieldPath: fieldPath,
 		})
 	}
 
@@ -34,13 +35,17 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
 				if p.RequirementsType == "request" || p.RequirementsType == "any" {
-					process(&results, container.Name, "request", container.Resources.Requests.Cpu(), p.LowerBoundMillis, p.UpperBoundMillis)
+					process(&results, container.Name, "request", "resources.requests.cpu", container.Resources.Requests.Cpu(), p.LowerBoundMillis, p.UpperBoundMillis)
 				}
 				if p.RequirementsType == "limit" || p.RequirementsType == "any" {
-					process(&results, container.Name, "limit", container.Resources.Limits.Cpu(), p.LowerBoundMillis, p.UpperBoundMillis)
+					process(&results, container.Name, "limit", "resources.limits.cpu", container.Resources.Limits.Cpu(), p.LowerBoundMillis, p.UpperBoundMillis)
 				}
 				return results
 			}), nil
@@ -0,0 +1,132 @@
+package deprecatedfields
+
+import (
+	// Embed the table of deprecations.
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+var (
+	//go:embed deprecations.yaml
+	deprecationsYAML []byte
+
+	loadOnce     sync.Once
+	deprecations []deprecation
+	loadErr      error
+)
+
+// The types of deprecations.
+const (
+	annotationType   = "annotation"
+	nodeLabelType    = "nodeLabel"
+	fieldType        = "field"
+	podSpecFieldType = "podSpecField"
+)
+
+// A deprecation describes a deprecated annotation, label or field.
+type deprecation struct {
+	Type string `json:"type"`
+	// Key is the key of the annotation or label, or the path of the field.
+	Key string `json:"key"`
+	// Kinds restricts the deprecation to objects of the given kinds.
+	Kinds        []string `json:"kinds,omitempty"`
+	DeprecatedIn string   `json:"deprecatedIn,omitempty"`
+	RemovedIn    string   `json:"removedIn,omitempty"`
+	Replacement  string   `json:"replacement"`
+
+	deprecatedIn, removedIn version
+}
+
+// matchesKey returns whether the given annotation or label key is the deprecated one.
+func (d *deprecation) matchesKey(key string) bool {
+	if prefix := strings.TrimSuffix(d.Key, "*"); prefix != d.Key {
+		return strings.HasPrefix(key, prefix)
+	}
+	return key == d.Key
+}
+
+func (d *deprecation) appliesToKind(kind string) bool {
+	if len(d.Kinds) == 0 {
+		return true
+	}
+	for _, k := range d.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDeprecations loads the embedded table of deprecations.
+func loadDeprecations() ([]deprecation, error) {
+	loadOnce.Do(func() {
+		if err := yaml.Unmarshal(deprecationsYAML, &deprecations); err != nil {
+			loadErr = errors.Wrap(err, "unmarshalling deprecations")
+			return
+		}
+		for i := range deprecations {
+			d := &deprecations[i]
+			switch d.Type {
+			case annotationType, nodeLabelType, fieldType, podSpecFieldType:
+			default:
+				loadErr = errors.Errorf("deprecation of %s has invalid type %q", d.Key, d.Type)
+				return
+			}
+			if d.deprecatedIn, loadErr = parseOptionalVersion(d.DeprecatedIn); loadErr != nil {
+				return
+			}
+			if d.removedIn, loadErr = parseOptionalVersion(d.RemovedIn); loadErr != nil {
+				return
+			}
+		}
+	})
+	return deprecations, loadErr
+}
+
+// A version is a Kubernetes minor version. The zero value stands for an unknown version.
+type version struct {
+	major, minor int
+}
+
+func (v version) isZero() bool {
+	return v == version{}
+}
+
+// atMost returns whether v is the same version as other, or an earlier one.
+func (v version) atMost(other version) bool {
+	return v.major < other.major || (v.major == other.major && v.minor <= other.minor)
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// parseVersion parses a Kubernetes version such as 1.30, v1.30 or 1.30.2. The patch version is ignored.
+func parseVersion(s string) (version, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return version{}, errors.Errorf("invalid Kubernetes version %q, expected a version such as 1.30", s)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return version{}, errors.Errorf("invalid Kubernetes version %q, expected a version such as 1.30", s)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return version{}, errors.Errorf("invalid Kubernetes version %q, expected a version such as 1.30", s)
+	}
+	return version{major: major, minor: minor}, nil
+}
+
+func parseOptionalVersion(s string) (version, error) {
+	if s == "" {
+		return version{}, nil
+	}
+	return parseVersion(s)
+}
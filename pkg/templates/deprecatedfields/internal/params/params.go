@@ -0,0 +1,10 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The Kubernetes version the objects are deployed to, e.g. 1.30. Annotations, labels and fields that are
+	// deprecated in later versions are not reported. If empty, all of them are reported.
+	// +example=1.30
+	KubernetesVersion string
+}
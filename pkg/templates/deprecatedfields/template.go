@@ -0,0 +1,227 @@
+package deprecatedfields
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/deprecatedfields/internal/params"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	templateKey = "deprecated-annotations-and-fields"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Deprecated Annotations and Fields",
+		Key:         templateKey,
+		Description: "Flag deprecated annotations, node labels and fields, from an embedded table of deprecations with the Kubernetes versions they were deprecated in and their replacements",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			target, err := parseOptionalVersion(p.KubernetesVersion)
+			if err != nil {
+				return nil, err
+			}
+			allDeprecations, err := loadDeprecations()
+			if err != nil {
+				return nil, err
+			}
+			var applicable []deprecation
+			for _, d := range allDeprecations {
+				if target.isZero() || d.deprecatedIn.atMost(target) {
+					applicable = append(applicable, d)
+				}
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				var results []diagnostic.Diagnostic
+				for _, usage := range findUsages(object.Source(), applicable) {
+					results = append(results, diagnostic.Diagnostic{Message: usage.message(target)})
+				}
+				return results
+			}, nil
+		}),
+	})
+}
+
+// A usage is a use of a deprecated annotation, label or field by an object.
+type usage struct {
+	deprecation *deprecation
+	key         string
+}
+
+func (u usage) message(target version) string {
+	d := u.deprecation
+	var subject string
+	switch d.Type {
+	case annotationType:
+		subject = fmt.Sprintf("annotation %q", u.key)
+	case nodeLabelType:
+		subject = fmt.Sprintf("node label %q", u.key)
+	case fieldType:
+		subject = fmt.Sprintf("field %s", u.key)
+	case podSpecFieldType:
+		subject = fmt.Sprintf("pod spec field %s", u.key)
+	}
+	status := "deprecated"
+	if !d.deprecatedIn.isZero() {
+		status += fmt.Sprintf(" since Kubernetes %s", d.deprecatedIn)
+	}
+	if !d.removedIn.isZero() && (target.isZero() || d.removedIn.atMost(target)) {
+		status += fmt.Sprintf(" and ignored since Kubernetes %s", d.removedIn)
+	}
+	return fmt.Sprintf("%s is %s: use %s instead", subject, status, d.Replacement)
+}
+
+// findUsages returns the uses of the given deprecations by the object.
+func findUsages(obj k8sutil.Object, deprecations []deprecation) []usage {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	annotations := objectAnnotations(obj)
+	podSpec, hasPodSpec := extract.PodSpec(obj)
+	var nodeLabels []string
+	var fields, podSpecFields set.StringSet
+	if hasPodSpec {
+		nodeLabels = nodeLabelKeys(&podSpec.PodSpec)
+	}
+
+	var usages []usage
+	for i := range deprecations {
+		d := &deprecations[i]
+		if !d.appliesToKind(kind) {
+			continue
+		}
+		switch d.Type {
+		case annotationType:
+			for _, key := range annotations {
+				if d.matchesKey(key) {
+					usages = append(usages, usage{deprecation: d, key: key})
+				}
+			}
+		case nodeLabelType:
+			for _, key := range nodeLabels {
+				if d.matchesKey(key) {
+					usages = append(usages, usage{deprecation: d, key: key})
+				}
+			}
+		case fieldType:
+			if fields == nil {
+				fields = fieldPaths(obj)
+			}
+			if fields.Contains(d.Key) {
+				usages = append(usages, usage{deprecation: d, key: d.Key})
+			}
+		case podSpecFieldType:
+			if !hasPodSpec {
+				continue
+			}
+			if podSpecFields == nil {
+				podSpecFields = fieldPaths(&podSpec.PodSpec)
+			}
+			if podSpecFields.Contains(d.Key) {
+				usages = append(usages, usage{deprecation: d, key: d.Key})
+			}
+		}
+	}
+	return usages
+}
+
+// objectAnnotations returns the sorted keys of the annotations of the object and of its pod template.
+func objectAnnotations(obj k8sutil.Object) []string {
+	keys := set.NewStringSet()
+	for key := range obj.GetAnnotations() {
+		keys.Add(key)
+	}
+	if podTemplate, found := extract.PodTemplateSpec(obj); found {
+		for key := range podTemplate.Annotations {
+			keys.Add(key)
+		}
+	}
+	return keys.AsSortedSlice(func(i, j string) bool {
+		return i < j
+	})
+}
+
+// nodeLabelKeys returns the sorted keys of the node labels that the pod spec refers to, in its node selector,
+// node affinity, pod (anti-)affinity and topology spread constraints.
+func nodeLabelKeys(podSpec *coreV1.PodSpec) []string {
+	keys := set.NewStringSet()
+	for key := range podSpec.NodeSelector {
+		keys.Add(key)
+	}
+	addTerm := func(term coreV1.NodeSelectorTerm) {
+		for _, requirement := range term.MatchExpressions {
+			keys.Add(requirement.Key)
+		}
+	}
+	addPodAffinityTerms := func(required []coreV1.PodAffinityTerm, preferred []coreV1.WeightedPodAffinityTerm) {
+		for _, term := range required {
+			keys.Add(term.TopologyKey)
+		}
+		for _, term := range preferred {
+			keys.Add(term.PodAffinityTerm.TopologyKey)
+		}
+	}
+	if affinity := podSpec.Affinity; affinity != nil {
+		if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+			if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+				for _, term := range nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+					addTerm(term)
+				}
+			}
+			for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+				addTerm(term.Preference)
+			}
+		}
+		if podAffinity := affinity.PodAffinity; podAffinity != nil {
+			addPodAffinityTerms(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+		}
+		if podAntiAffinity := affinity.PodAntiAffinity; podAntiAffinity != nil {
+			addPodAffinityTerms(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+		}
+	}
+	for _, constraint := range podSpec.TopologySpreadConstraints {
+		keys.Add(constraint.TopologyKey)
+	}
+	return keys.AsSortedSlice(func(i, j string) bool {
+		return i < j
+	})
+}
+
+// fieldPaths returns the dot-separated paths of all the fields that are set in the given object. Lists don't
+// add a path segment, so the paths of the fields of list elements start with the path of the list.
+func fieldPaths(obj interface{}) set.StringSet {
+	paths := set.NewStringSet()
+	if content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err == nil {
+		collectFieldPaths(nil, content, paths)
+	}
+	return paths
+}
+
+func collectFieldPaths(prefix []string, value interface{}, paths set.StringSet) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range value {
+			path := append(prefix[:len(prefix):len(prefix)], key)
+			paths.Add(strings.Join(path, "."))
+			collectFieldPaths(path, elem, paths)
+		}
+	case []interface{}:
+		for _, elem := range value {
+			collectFieldPaths(prefix, elem, paths)
+		}
+	}
+}
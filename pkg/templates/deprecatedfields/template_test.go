@@ -0,0 +1,128 @@
+package deprecatedfields
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/deprecatedfields/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeprecatedFields(t *testing.T) {
+	suite.Run(t, new(DeprecatedFieldsTestSuite))
+}
+
+type DeprecatedFieldsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *DeprecatedFieldsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *DeprecatedFieldsTestSuite) addObjects() {
+	s.ctx.AddObject("app", &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "app"},
+		Spec: appsV1.DeploymentSpec{
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{
+					"seccomp.security.alpha.kubernetes.io/pod":             "runtime/default",
+					"container.apparmor.security.beta.kubernetes.io/app":   "runtime/default",
+					"container.apparmor.security.beta.kubernetes.io/proxy": "runtime/default",
+					// The ingress class annotation is only deprecated on Ingresses.
+					"kubernetes.io/ingress.class": "nginx",
+				}},
+				Spec: coreV1.PodSpec{
+					DeprecatedServiceAccount: "app",
+					NodeSelector:             map[string]string{"failure-domain.beta.kubernetes.io/zone": "zone-a"},
+					TopologySpreadConstraints: []coreV1.TopologySpreadConstraint{
+						{TopologyKey: "topology.kubernetes.io/zone"},
+					},
+				},
+			},
+		},
+	})
+	s.ctx.AddObject("critical", &coreV1.Pod{
+		TypeMeta: metaV1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "critical",
+			Annotations: map[string]string{"scheduler.alpha.kubernetes.io/critical-pod": ""},
+		},
+	})
+	s.ctx.AddObject("ingress", &networkingV1.Ingress{
+		TypeMeta: metaV1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "ingress",
+			Annotations: map[string]string{"kubernetes.io/ingress.class": "nginx"},
+		},
+	})
+	s.ctx.AddObject("service", &coreV1.Service{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "service"},
+		Spec:       coreV1.ServiceSpec{Type: coreV1.ServiceTypeLoadBalancer, LoadBalancerIP: "10.0.0.1"},
+	})
+}
+
+func (s *DeprecatedFieldsTestSuite) TestDeprecations() {
+	s.addObjects()
+
+	const (
+		seccomp        = `annotation "seccomp.security.alpha.kubernetes.io/pod" is deprecated since Kubernetes 1.19 and ignored since Kubernetes 1.27: use the seccompProfile field of the pod security context instead`
+		appArmorApp    = `annotation "container.apparmor.security.beta.kubernetes.io/app" is deprecated since Kubernetes 1.30: use the appArmorProfile field of the pod or container security context instead`
+		appArmorProxy  = `annotation "container.apparmor.security.beta.kubernetes.io/proxy" is deprecated since Kubernetes 1.30: use the appArmorProfile field of the pod or container security context instead`
+		zone           = `node label "failure-domain.beta.kubernetes.io/zone" is deprecated since Kubernetes 1.17: use topology.kubernetes.io/zone instead`
+		serviceAccount = `pod spec field serviceAccount is deprecated: use serviceAccountName instead`
+		ingressClass   = `annotation "kubernetes.io/ingress.class" is deprecated since Kubernetes 1.18: use spec.ingressClassName instead`
+		loadBalancerIP = `field spec.loadBalancerIP is deprecated since Kubernetes 1.24: use the annotations of the load balancer implementation instead`
+	)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {{Message: seccomp}, {Message: appArmorApp}, {Message: appArmorProxy}, {Message: zone}, {Message: serviceAccount}},
+				"critical": {
+					{Message: `annotation "scheduler.alpha.kubernetes.io/critical-pod" is deprecated since Kubernetes 1.13 and ignored since Kubernetes 1.16: use priorityClassName system-cluster-critical or system-node-critical instead`},
+				},
+				"ingress": {{Message: ingressClass}},
+				"service": {{Message: loadBalancerIP}},
+			},
+		},
+		{
+			Param: params.Params{KubernetesVersion: "1.15"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {{Message: serviceAccount}},
+				"critical": {
+					{Message: `annotation "scheduler.alpha.kubernetes.io/critical-pod" is deprecated since Kubernetes 1.13: use priorityClassName system-cluster-critical or system-node-critical instead`},
+				},
+				"ingress": {},
+				"service": {},
+			},
+		},
+		{
+			Param: params.Params{KubernetesVersion: "v1.29.3"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {{Message: seccomp}, {Message: zone}, {Message: serviceAccount}},
+				"critical": {
+					{Message: `annotation "scheduler.alpha.kubernetes.io/critical-pod" is deprecated since Kubernetes 1.13 and ignored since Kubernetes 1.16: use priorityClassName system-cluster-critical or system-node-critical instead`},
+				},
+				"ingress": {{Message: ingressClass}},
+				"service": {{Message: loadBalancerIP}},
+			},
+		},
+		{
+			Param:                    params.Params{KubernetesVersion: "latest"},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	groupParamDesc = util.MustParseParameterDesc(`{
 	"Name": "group",
@@ -28,8 +30,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "Group",
 	"XXXIsPointer": false
 }
@@ -47,8 +48,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "Version",
 	"XXXIsPointer": false
 }
@@ -66,8 +66,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "Kind",
 	"XXXIsPointer": false
 }
@@ -82,6 +81,15 @@ var (
 
 func (p *Params) Validate() error {
 	var validationErrors []string
+	if err := matcher.Validate(p.Group, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param group has invalid value %q: %v", p.Group, err))
+	}
+	if err := matcher.Validate(p.Version, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param version has invalid value %q: %v", p.Version, err))
+	}
+	if err := matcher.Validate(p.Kind, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param kind has invalid value %q: %v", p.Kind, err))
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
@@ -5,15 +5,18 @@ type Params struct {
 
 	// The disallowed object group.
 	// +example=apps
+	// +matcher
 	Group string `json:"group"`
 
 	// The disallowed object API version.
 	// +example=v1
 	// +example=v1beta1
+	// +matcher
 	Version string
 
 	// The disallowed kind.
 	// +example=Deployment
 	// +example=DaemonSet
+	// +matcher
 	Kind string
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	keyParamDesc = util.MustParseParameterDesc(`{
 	"Name": "key",
@@ -26,8 +28,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "",
 	"XXXStructFieldName": "Key",
 	"XXXIsPointer": false
 }
@@ -42,8 +43,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "",
 	"XXXStructFieldName": "Value",
 	"XXXIsPointer": false
 }
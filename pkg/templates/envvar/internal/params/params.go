@@ -1,12 +1,20 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 
 	// The name of the environment variable.
 	// +required
+	// +matcher
 	Name string
 
 	// The value of the environment variable.
+	// +matcher
 	Value string
+
+	util.ContainerFilterParams
 }
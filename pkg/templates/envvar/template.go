@@ -34,12 +34,17 @@ func init() {
 			if err != nil {
 				return nil, errors.Wrap(err, "invalid value")
 			}
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, envVar := range container.Env {
+				for i, envVar := range container.Env {
 					if nameMatcher(envVar.Name) && valueMatcher(envVar.Value) {
 						results = append(results, diagnostic.Diagnostic{
-							Message: fmt.Sprintf("environment variable %s in container %q found", envVar.Name, container.Name),
+							Message:   fmt.Sprintf("environment variable %s in container %q found", envVar.Name, container.Name),
+							FieldPath: fmt.Sprintf("env[%d].value", i),
 						})
 					}
 				}
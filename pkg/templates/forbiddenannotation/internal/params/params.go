@@ -5,8 +5,10 @@ type Params struct {
 
 	// Key of the forbidden annotation.
 	// +required
+	// +matcher
 	Key string
 
 	// Value of the forbidden annotation.
+	// +matcher
 	Value string
 }
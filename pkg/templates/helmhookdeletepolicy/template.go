@@ -0,0 +1,68 @@
+package helmhookdeletepolicy
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmhookdeletepolicy/internal/params"
+	batchV1 "k8s.io/api/batch/v1"
+)
+
+const (
+	templateKey = "helm-hook-delete-policy"
+
+	beforeHookCreation = "before-hook-creation"
+	hookSucceeded      = "hook-succeeded"
+	hookFailed         = "hook-failed"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Helm Hook Delete Policy",
+		Key:         templateKey,
+		Description: "Flag Jobs rendered from Helm charts that are hooks without a delete policy, or whose delete policy doesn't delete them before the hook is created again",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				if object.Metadata.HelmChart == nil {
+					return nil
+				}
+				if _, isJob := object.K8sObject.(*batchV1.Job); !isJob {
+					return nil
+				}
+				hooks := extract.HelmHooks(object.K8sObject)
+				if len(hooks) == 0 {
+					return nil
+				}
+				policies := extract.HelmHookDeletePolicies(object.K8sObject)
+				if len(policies) == 0 {
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("%s hook Job has no %s annotation, so the finished Job is left in the cluster, "+
+							"where it can make the next upgrade fail", strings.Join(hooks, ","), extract.HelmHookDeletePolicyAnnotation),
+					}}
+				}
+				// The Job is always deleted before the hook runs again if it is deleted however the hook ends.
+				policySet := set.NewStringSet(policies...)
+				if !policySet.Contains(beforeHookCreation) && !(policySet.Contains(hookSucceeded) && policySet.Contains(hookFailed)) {
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("%s hook Job has the delete policy %s, which doesn't include %s, so a Job left by a previous "+
+							"run makes the next upgrade fail", strings.Join(hooks, ","), strings.Join(policies, ","), beforeHookCreation),
+					}}
+				}
+				return nil
+			}, nil
+		}),
+	})
+}
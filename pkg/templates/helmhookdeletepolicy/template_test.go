@@ -0,0 +1,110 @@
+package helmhookdeletepolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmhookdeletepolicy/internal/params"
+	batchV1 "k8s.io/api/batch/v1"
+)
+
+const (
+	jobsYAML = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    helm.sh/hook: pre-install,pre-upgrade
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: seed
+  annotations:
+    helm.sh/hook: post-install
+    helm.sh/hook-delete-policy: hook-succeeded
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: cleanup
+  annotations:
+    helm.sh/hook: pre-delete
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-delete-policy: hook-succeeded, hook-failed
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: not-a-hook
+`
+)
+
+func TestHelmHookDeletePolicy(t *testing.T) {
+	suite.Run(t, new(HelmHookDeletePolicyTestSuite))
+}
+
+type HelmHookDeletePolicyTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *HelmHookDeletePolicyTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *HelmHookDeletePolicyTestSuite) addJobs(fromHelmChart bool) {
+	chart := &lintcontext.HelmChartMetadata{Path: "charts/app", Name: "app"}
+	for _, doc := range strings.Split(jobsYAML, "---\n") {
+		var job batchV1.Job
+		s.Require().NoError(yaml.Unmarshal([]byte(doc), &job))
+		s.ctx.AddObject(job.Name, &job)
+		if fromHelmChart {
+			s.ctx.SetHelmChart(job.Name, "charts/app/templates/jobs.yaml", chart)
+		}
+	}
+}
+
+func (s *HelmHookDeletePolicyTestSuite) TestHelmChart() {
+	s.addJobs(true)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"migrate": {
+					{Message: "pre-install,pre-upgrade hook Job has no helm.sh/hook-delete-policy annotation, so the finished Job is left in the cluster, where it can make the next upgrade fail"},
+				},
+				"seed": {
+					{Message: "post-install hook Job has the delete policy hook-succeeded, which doesn't include before-hook-creation, so a Job left by a previous run makes the next upgrade fail"},
+				},
+			},
+		},
+	})
+}
+
+func (s *HelmHookDeletePolicyTestSuite) TestNotHelmChart() {
+	s.addJobs(false)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param:       params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{},
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package helmhookorder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmhookorder/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "helm-hook-order"
+)
+
+// preHooks are the hooks that run before the objects of the chart that are not hooks are installed or updated.
+var preHooks = set.NewFrozenStringSet("pre-install", "pre-upgrade", "pre-rollback")
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Helm Hook Order",
+		Key:         templateKey,
+		Description: "Flag hooks rendered from Helm charts that use Secrets of the same chart which Helm creates after them, because the Secrets have a higher hook weight or are not hooks at all",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				if object.Metadata.HelmChart == nil {
+					return nil
+				}
+				hooks := extract.HelmHooks(object.K8sObject)
+				if len(hooks) == 0 {
+					return nil
+				}
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				secrets := chartSecrets(lintCtx, object)
+				kind := object.K8sObject.GetObjectKind().GroupVersionKind().Kind
+				weight := extract.HelmHookWeight(object.K8sObject)
+
+				var results []diagnostic.Diagnostic
+				for _, name := range referencedSecrets(&podSpec.PodSpec) {
+					secret, found := secrets[name]
+					if !found {
+						continue
+					}
+					secretHooks := set.NewStringSet(extract.HelmHooks(secret.K8sObject)...)
+					if secretHooks.IsEmpty() {
+						if phases := preHookPhases(hooks); len(phases) > 0 {
+							results = append(results, diagnostic.Diagnostic{
+								Message: fmt.Sprintf("%s hook %s uses Secret %q, which is not a hook, so it is only installed or updated after the %s hooks have run",
+									strings.Join(hooks, ","), kind, name, strings.Join(phases, ",")),
+							})
+						}
+						continue
+					}
+					secretWeight := extract.HelmHookWeight(secret.K8sObject)
+					if secretWeight <= weight {
+						continue
+					}
+					var phases []string
+					for _, hook := range hooks {
+						if secretHooks.Contains(hook) {
+							phases = append(phases, hook)
+						}
+					}
+					if len(phases) > 0 {
+						results = append(results, diagnostic.Diagnostic{
+							Message: fmt.Sprintf("%s hook %s with weight %d uses Secret %q, whose weight %d makes Helm create it after the %s in the %s phase",
+								strings.Join(hooks, ","), kind, weight, name, secretWeight, kind, strings.Join(phases, ",")),
+						})
+					}
+				}
+				return results
+			}, nil
+		}),
+	})
+}
+
+func preHookPhases(hooks []string) []string {
+	var phases []string
+	for _, hook := range hooks {
+		if preHooks.Contains(hook) {
+			phases = append(phases, hook)
+		}
+	}
+	return phases
+}
+
+// chartSecrets returns the Secrets rendered from the same chart as the given object, in its namespace, by name.
+func chartSecrets(lintCtx lintcontext.LintContext, object lintcontext.Object) map[string]lintcontext.Object {
+	secrets := make(map[string]lintcontext.Object)
+	for _, other := range lintCtx.Objects() {
+		if _, isSecret := other.K8sObject.(*v1.Secret); !isSecret {
+			continue
+		}
+		if other.Metadata.HelmChart == nil || other.Metadata.HelmChart.Path != object.Metadata.HelmChart.Path ||
+			other.K8sObject.GetNamespace() != object.K8sObject.GetNamespace() {
+			continue
+		}
+		secrets[other.K8sObject.GetName()] = other
+	}
+	return secrets
+}
+
+// referencedSecrets returns the sorted names of the Secrets the pod spec uses.
+func referencedSecrets(podSpec *v1.PodSpec) []string {
+	names := set.NewStringSet()
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			for _, env := range containers[i].Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					names.Add(env.ValueFrom.SecretKeyRef.Name)
+				}
+			}
+			for _, envFrom := range containers[i].EnvFrom {
+				if envFrom.SecretRef != nil {
+					names.Add(envFrom.SecretRef.Name)
+				}
+			}
+		}
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			names.Add(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					names.Add(source.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, pullSecret := range podSpec.ImagePullSecrets {
+		names.Add(pullSecret.Name)
+	}
+	sorted := names.AsSlice()
+	sort.Strings(sorted)
+	return sorted
+}
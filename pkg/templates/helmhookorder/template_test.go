@@ -0,0 +1,130 @@
+package helmhookorder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmhookorder/internal/params"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	secretsYAML = `apiVersion: v1
+kind: Secret
+metadata:
+  name: db-credentials
+  annotations:
+    helm.sh/hook: pre-install,pre-upgrade
+    helm.sh/hook-weight: "5"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: tls
+  annotations:
+    helm.sh/hook: pre-install,pre-upgrade
+    helm.sh/hook-weight: "-5"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-config
+`
+
+	jobsYAML = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    helm.sh/hook: pre-install
+spec:
+  template:
+    spec:
+      imagePullSecrets:
+      - name: registry
+      containers:
+      - name: migrate
+        env:
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: db-credentials
+              key: password
+        envFrom:
+        - secretRef:
+            name: app-config
+      volumes:
+      - name: tls
+        secret:
+          secretName: tls
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: notify
+  annotations:
+    helm.sh/hook: post-install
+    helm.sh/hook-weight: "10"
+spec:
+  template:
+    spec:
+      containers:
+      - name: notify
+        envFrom:
+        - secretRef:
+            name: app-config
+        - secretRef:
+            name: db-credentials
+`
+)
+
+func TestHelmHookOrder(t *testing.T) {
+	suite.Run(t, new(HelmHookOrderTestSuite))
+}
+
+type HelmHookOrderTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *HelmHookOrderTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *HelmHookOrderTestSuite) addObjects(source string, newObject func() k8sutil.Object) {
+	chart := &lintcontext.HelmChartMetadata{Path: "charts/app", Name: "app"}
+	for _, doc := range strings.Split(source, "---\n") {
+		obj := newObject()
+		s.Require().NoError(yaml.Unmarshal([]byte(doc), obj))
+		s.ctx.AddObject(obj.GetName(), obj)
+		s.ctx.SetHelmChart(obj.GetName(), "charts/app/templates/"+obj.GetName()+".yaml", chart)
+	}
+}
+
+func (s *HelmHookOrderTestSuite) TestHookOrder() {
+	s.addObjects(secretsYAML, func() k8sutil.Object { return &coreV1.Secret{} })
+	s.addObjects(jobsYAML, func() k8sutil.Object { return &batchV1.Job{} })
+
+	// The registry Secret is not part of the chart.
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"migrate": {
+					{Message: `pre-install hook Job uses Secret "app-config", which is not a hook, so it is only installed or updated after the pre-install hooks have run`},
+					{Message: `pre-install hook Job with weight 0 uses Secret "db-credentials", whose weight 5 makes Helm create it after the Job in the pre-install phase`},
+				},
+			},
+		},
+	})
+}
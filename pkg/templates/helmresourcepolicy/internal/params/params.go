@@ -0,0 +1,10 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The kinds of namespaced objects that are ephemeral, i.e. that are replaced rather than kept across releases.
+	// +example=Job
+	// +example=Pod
+	Kinds []string `json:"kinds"`
+}
@@ -0,0 +1,50 @@
+package helmresourcepolicy
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmresourcepolicy/internal/params"
+)
+
+const (
+	templateKey = "helm-resource-policy"
+
+	keepPolicy = "keep"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Helm Resource Policy",
+		Key:         templateKey,
+		Description: "Flag ephemeral objects rendered from Helm charts that have the helm.sh/resource-policy: keep annotation, which leaves them behind when the release is uninstalled",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			kinds := set.NewFrozenStringSet(p.Kinds...)
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				if object.Metadata.HelmChart == nil {
+					return nil
+				}
+				kind := object.K8sObject.GetObjectKind().GroupVersionKind().Kind
+				if !kinds.Contains(kind) || object.K8sObject.GetAnnotations()[extract.HelmResourcePolicyAnnotation] != keepPolicy {
+					return nil
+				}
+				return []diagnostic.Diagnostic{{
+					Message: fmt.Sprintf("%s has %s: %s, so it is left behind when the release is uninstalled, "+
+						"and conflicts with the %s of the next install of the release", kind, extract.HelmResourcePolicyAnnotation, keepPolicy, kind),
+				}}
+			}, nil
+		}),
+	})
+}
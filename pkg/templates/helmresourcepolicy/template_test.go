@@ -0,0 +1,68 @@
+package helmresourcepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmresourcepolicy/internal/params"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHelmResourcePolicy(t *testing.T) {
+	suite.Run(t, new(HelmResourcePolicyTestSuite))
+}
+
+type HelmResourcePolicyTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *HelmResourcePolicyTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func keep() metaV1.ObjectMeta {
+	return metaV1.ObjectMeta{Annotations: map[string]string{"helm.sh/resource-policy": "keep"}}
+}
+
+func (s *HelmResourcePolicyTestSuite) TestResourcePolicy() {
+	chart := &lintcontext.HelmChartMetadata{Path: "charts/app", Name: "app"}
+
+	job := &batchV1.Job{TypeMeta: metaV1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"}, ObjectMeta: keep()}
+	job.Name = "migrate"
+	s.ctx.AddObject(job.Name, job)
+	s.ctx.SetHelmChart(job.Name, "charts/app/templates/job.yaml", chart)
+
+	claim := &coreV1.PersistentVolumeClaim{TypeMeta: metaV1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"}, ObjectMeta: keep()}
+	claim.Name = "data"
+	s.ctx.AddObject(claim.Name, claim)
+	s.ctx.SetHelmChart(claim.Name, "charts/app/templates/pvc.yaml", chart)
+
+	// Objects that are not rendered from a Helm chart are not checked.
+	pod := &coreV1.Pod{TypeMeta: metaV1.TypeMeta{Kind: "Pod", APIVersion: "v1"}, ObjectMeta: keep()}
+	pod.Name = "debug"
+	s.ctx.AddObject(pod.Name, pod)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Kinds: []string{"Pod", "Job"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"migrate": {
+					{Message: "Job has helm.sh/resource-policy: keep, so it is left behind when the release is uninstalled, and conflicts with the Job of the next install of the release"},
+				},
+			},
+		},
+		{
+			Param:       params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{},
+		},
+	})
+}
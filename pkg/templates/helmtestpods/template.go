@@ -0,0 +1,58 @@
+package helmtestpods
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmtestpods/internal/params"
+)
+
+const (
+	templateKey = "helm-test-pods"
+
+	testsDirectory = "/templates/tests/"
+)
+
+// testHooks are the hooks that make Helm run an object as a test, test-success being the name used by Helm 2.
+var testHooks = set.NewFrozenStringSet("test", "test-success")
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Helm Test Pods",
+		Key:         templateKey,
+		Description: "Flag the pods under templates/tests of Helm charts that are not declared as test hooks",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				if object.Metadata.HelmChart == nil {
+					return nil
+				}
+				if !strings.Contains("/"+filepath.ToSlash(object.Metadata.FilePath), testsDirectory) {
+					return nil
+				}
+				for _, hook := range extract.HelmHooks(object.K8sObject) {
+					if testHooks.Contains(hook) {
+						return nil
+					}
+				}
+				return []diagnostic.Diagnostic{{
+					Message: fmt.Sprintf("%s under templates/tests has no %s: test annotation, so Helm installs it with the release instead of running it as a test",
+						object.K8sObject.GetObjectKind().GroupVersionKind().Kind, extract.HelmHookAnnotation),
+				}}
+			}, nil
+		}),
+	})
+}
@@ -0,0 +1,93 @@
+package helmtestpods
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/helmtestpods/internal/params"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	testConnectionYAML = `apiVersion: v1
+kind: Pod
+metadata:
+  name: test-connection
+  annotations:
+    helm.sh/hook: test
+spec:
+  containers:
+  - name: wget
+    image: busybox
+    securityContext:
+      privileged: true
+`
+
+	testDataYAML = `apiVersion: v1
+kind: Pod
+metadata:
+  name: test-data
+spec:
+  securityContext:
+    runAsNonRoot: true
+  containers:
+  - name: check
+    image: busybox
+`
+
+	appYAML = `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  containers:
+  - name: app
+    image: app
+    securityContext:
+      privileged: true
+`
+)
+
+func TestHelmTestPods(t *testing.T) {
+	suite.Run(t, new(HelmTestPodsTestSuite))
+}
+
+type HelmTestPodsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *HelmTestPodsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *HelmTestPodsTestSuite) addPod(raw, filePath string) {
+	var pod coreV1.Pod
+	s.Require().NoError(yaml.Unmarshal([]byte(raw), &pod))
+	s.ctx.AddObject(pod.Name, &pod)
+	s.ctx.SetHelmChart(pod.Name, filePath, &lintcontext.HelmChartMetadata{Path: "charts/app", Name: "app"})
+}
+
+func (s *HelmTestPodsTestSuite) TestTestPods() {
+	s.addPod(testConnectionYAML, "charts/app/templates/tests/test-connection.yaml")
+	s.addPod(testDataYAML, "charts/app/templates/tests/test-data.yaml")
+	s.addPod(appYAML, "charts/app/templates/pod.yaml")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"test-data": {
+					{Message: "Pod under templates/tests has no helm.sh/hook: test annotation, so Helm installs it with the release instead of running it as a test"},
+				},
+			},
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,18 +17,18 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	dirsParamDesc = util.MustParseParameterDesc(`{
 	"Name": "dirs",
 	"Type": "array",
-	"Description": "An array of regular expressions specifying system directories to be mounted on containers. e.g. ^/usr$ for /usr",
+	"Description": "An array of matchers specifying system directories to be mounted on containers. e.g. ^/usr$ for /usr",
 	"Examples": null,
 	"Enum": null,
 	"SubParameters": null,
 	"ArrayElemType": "string",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": true,
+	"Matcher": "non-negatable",
 	"XXXStructFieldName": "Dirs",
 	"XXXIsPointer": false
 }
@@ -40,6 +41,11 @@ var (
 
 func (p *Params) Validate() error {
 	var validationErrors []string
+	for _, value := range p.Dirs {
+		if err := matcher.Validate(value, false); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("param dirs has invalid value %q: %v", value, err))
+		}
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
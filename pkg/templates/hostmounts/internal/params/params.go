@@ -2,7 +2,7 @@ package params
 
 // Params represents the params accepted by this template.
 type Params struct {
-	// An array of regular expressions specifying system directories to be mounted on containers. e.g. ^/usr$ for /usr
-	// +notnegatable
+	// An array of matchers specifying system directories to be mounted on containers. e.g. ^/usr$ for /usr
+	// +matcher=non-negatable
 	Dirs []string `json:"dirs"`
 }
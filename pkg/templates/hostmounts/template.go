@@ -2,7 +2,6 @@ package hostmounts
 
 import (
 	"fmt"
-	"regexp"
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
@@ -10,9 +9,11 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/extract"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostmounts/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 const (
@@ -30,34 +31,38 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
-			compiledRegexes := make([]*regexp.Regexp, 0, len(p.Dirs))
+			dirMatchers := make([]func(string) bool, 0, len(p.Dirs))
 			for _, dir := range p.Dirs {
-				r, err := regexp.Compile(dir)
+				m, err := matcher.ForString(dir)
 				if err != nil {
-					return nil, errors.Wrapf(err, "invalid regex %s", dir)
+					return nil, errors.Wrapf(err, "invalid dir %s", dir)
 				}
-				compiledRegexes = append(compiledRegexes, r)
+				dirMatchers = append(dirMatchers, m)
 			}
 			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 				podSpec, found := extract.PodSpec(object.K8sObject)
 				if !found {
 					return nil
 				}
+				containerPaths := util.ContainerPaths(object.K8sObject)
 				var results []diagnostic.Diagnostic
 				containers := podSpec.AllContainers()
 				for _, v := range podSpec.Volumes {
 					if v.HostPath == nil {
 						continue
 					}
-					for _, regex := range compiledRegexes {
-						if !regex.MatchString(v.HostPath.Path) {
+					for _, dirMatcher := range dirMatchers {
+						if !dirMatcher(v.HostPath.Path) {
 							continue
 						}
 						for _, container := range containers {
-							for _, mount := range container.VolumeMounts {
+							for i, mount := range container.VolumeMounts {
 								if mount.Name == v.Name {
 									results = append(results, diagnostic.Diagnostic{
-										Message: fmt.Sprintf("host system directory %q is mounted on container %q", v.HostPath.Path, container.Name)})
+										Message:   fmt.Sprintf("host system directory %q is mounted on container %q", v.HostPath.Path, container.Name),
+										Container: container.Name,
+										FieldPath: fmt.Sprintf("%s.volumeMounts[%d]", containerPaths[container.Name], i),
+									})
 								}
 							}
 						}
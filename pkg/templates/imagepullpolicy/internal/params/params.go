@@ -1,12 +1,16 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 	// list of forbidden image pull policy
-	// +noregex
-	// +notnegatable
 	// +enum=Always
 	// +enum=IfNotPresent
 	// +enum=Never
 	ForbiddenPolicies []string
+
+	util.ContainerFilterParams
 }
@@ -30,9 +30,16 @@ func init() {
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
 			forbiddenPolicies := set.NewStringSet(p.ForbiddenPolicies...)
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				if forbiddenPolicies.Contains(string(container.ImagePullPolicy)) {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has imagePullPolicy set to %s", container.Name, container.ImagePullPolicy)}}
+					return []diagnostic.Diagnostic{{
+						Message:   fmt.Sprintf("container %q has imagePullPolicy set to %s", container.Name, container.ImagePullPolicy),
+						FieldPath: "imagePullPolicy",
+					}}
 				}
 				return nil
 			}), nil
@@ -0,0 +1,144 @@
+package immutablefields
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/immutablefields/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const (
+	templateKey = "immutable-fields"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Immutable Fields",
+		Key:         templateKey,
+		Description: "Flag changes since the previous revision of the manifests to fields that can't be updated, such as the selector of a Deployment, which the API server rejects",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				transitionCtx, ok := lintCtx.(lintcontext.TransitionContext)
+				if !ok {
+					return nil
+				}
+				previous, found := transitionCtx.PreviousObject(object)
+				if !found {
+					return nil
+				}
+				kind := object.K8sObject.GetObjectKind().GroupVersionKind().Kind
+				var results []diagnostic.Diagnostic
+				for _, change := range changedImmutableFields(previous.K8sObject, object.K8sObject) {
+					results = append(results, diagnostic.Diagnostic{
+						Message: fmt.Sprintf("%s, but the field is immutable: the %s has to be deleted and recreated to apply the change", change, kind),
+					})
+				}
+				return results
+			}, nil
+		}),
+	})
+}
+
+// changes collects descriptions of the immutable fields that changed between two revisions of an object.
+type changes []string
+
+func (c *changes) compare(path string, previous, current interface{}) {
+	if !equality.Semantic.DeepEqual(previous, current) {
+		*c = append(*c, fmt.Sprintf("%s changed", path))
+	}
+}
+
+func (c *changes) compareString(path string, previous, current string) {
+	if previous != current {
+		*c = append(*c, fmt.Sprintf("%s changed from %q to %q", path, previous, current))
+	}
+}
+
+// changedImmutableFields describes the immutable fields that changed between the previous and the current revision
+// of an object. Objects whose type changed, e.g. because they moved to another API version, are not compared.
+func changedImmutableFields(previous, current k8sutil.Object) []string {
+	var c changes
+	switch current := current.(type) {
+	case *appsV1.Deployment:
+		if previous, ok := previous.(*appsV1.Deployment); ok {
+			c.compare("spec.selector", previous.Spec.Selector, current.Spec.Selector)
+		}
+	case *appsV1.ReplicaSet:
+		if previous, ok := previous.(*appsV1.ReplicaSet); ok {
+			c.compare("spec.selector", previous.Spec.Selector, current.Spec.Selector)
+		}
+	case *appsV1.DaemonSet:
+		if previous, ok := previous.(*appsV1.DaemonSet); ok {
+			c.compare("spec.selector", previous.Spec.Selector, current.Spec.Selector)
+		}
+	case *appsV1.StatefulSet:
+		if previous, ok := previous.(*appsV1.StatefulSet); ok {
+			c.compare("spec.selector", previous.Spec.Selector, current.Spec.Selector)
+			c.compareString("spec.serviceName", previous.Spec.ServiceName, current.Spec.ServiceName)
+			c.compareString("spec.podManagementPolicy", string(previous.Spec.PodManagementPolicy), string(current.Spec.PodManagementPolicy))
+			c.compare("spec.volumeClaimTemplates", previous.Spec.VolumeClaimTemplates, current.Spec.VolumeClaimTemplates)
+		}
+	case *batchV1.Job:
+		if previous, ok := previous.(*batchV1.Job); ok {
+			c.compare("spec.selector", previous.Spec.Selector, current.Spec.Selector)
+			c.compare("spec.template", previous.Spec.Template, current.Spec.Template)
+			c.compare("spec.completionMode", previous.Spec.CompletionMode, current.Spec.CompletionMode)
+		}
+	case *coreV1.Service:
+		// The cluster IP is usually allocated by the API server, so it is only compared if both revisions set it.
+		if previous, ok := previous.(*coreV1.Service); ok && previous.Spec.ClusterIP != "" && current.Spec.ClusterIP != "" {
+			c.compareString("spec.clusterIP", previous.Spec.ClusterIP, current.Spec.ClusterIP)
+		}
+	case *coreV1.ConfigMap:
+		if previous, ok := previous.(*coreV1.ConfigMap); ok && isTrue(previous.Immutable) {
+			c.compare("immutable", previous.Immutable, current.Immutable)
+			c.compare("data", previous.Data, current.Data)
+			c.compare("binaryData", previous.BinaryData, current.BinaryData)
+		}
+	case *coreV1.Secret:
+		if previous, ok := previous.(*coreV1.Secret); ok {
+			c.compareString("type", string(previous.Type), string(current.Type))
+			if isTrue(previous.Immutable) {
+				c.compare("immutable", previous.Immutable, current.Immutable)
+				c.compare("data", previous.Data, current.Data)
+				c.compare("stringData", previous.StringData, current.StringData)
+			}
+		}
+	case *coreV1.PersistentVolumeClaim:
+		if previous, ok := previous.(*coreV1.PersistentVolumeClaim); ok {
+			c.compare("spec.accessModes", previous.Spec.AccessModes, current.Spec.AccessModes)
+			c.compare("spec.selector", previous.Spec.Selector, current.Spec.Selector)
+			c.compare("spec.storageClassName", previous.Spec.StorageClassName, current.Spec.StorageClassName)
+			c.compare("spec.volumeMode", previous.Spec.VolumeMode, current.Spec.VolumeMode)
+		}
+	case *rbacV1.RoleBinding:
+		if previous, ok := previous.(*rbacV1.RoleBinding); ok {
+			c.compare("roleRef", previous.RoleRef, current.RoleRef)
+		}
+	case *rbacV1.ClusterRoleBinding:
+		if previous, ok := previous.(*rbacV1.ClusterRoleBinding); ok {
+			c.compare("roleRef", previous.RoleRef, current.RoleRef)
+		}
+	}
+	return c
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
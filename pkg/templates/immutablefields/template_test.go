@@ -0,0 +1,104 @@
+package immutablefields
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/immutablefields/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImmutableFields(t *testing.T) {
+	suite.Run(t, new(ImmutableFieldsTestSuite))
+}
+
+type ImmutableFieldsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ImmutableFieldsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func statefulSet(name, serviceName, app string) *appsV1.StatefulSet {
+	return &appsV1.StatefulSet{
+		TypeMeta:   metaV1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Spec: appsV1.StatefulSetSpec{
+			Selector:    &metaV1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			ServiceName: serviceName,
+		},
+	}
+}
+
+func (s *ImmutableFieldsTestSuite) addDeployment(name, app string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.TypeMeta = metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}
+		deployment.Spec.Selector = &metaV1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+	})
+}
+
+func (s *ImmutableFieldsTestSuite) TestChangedFields() {
+	const (
+		unchanged = "unchanged"
+		changed   = "changed"
+		added     = "added"
+		database  = "database"
+		service   = "service"
+	)
+	s.addDeployment(unchanged, unchanged)
+	s.ctx.AddPreviousObject(&appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: unchanged},
+		Spec:       appsV1.DeploymentSpec{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": unchanged}}},
+	})
+	s.addDeployment(changed, "new")
+	s.ctx.AddPreviousObject(&appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: changed},
+		Spec:       appsV1.DeploymentSpec{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "old"}}},
+	})
+	s.addDeployment(added, added)
+
+	s.ctx.AddObject(database, statefulSet(database, "database-headless", database))
+	s.ctx.AddPreviousObject(statefulSet(database, "database", database))
+
+	s.ctx.AddMockService(s.T(), service)
+	s.ctx.ModifyService(s.T(), service, func(svc *coreV1.Service) {
+		svc.TypeMeta = metaV1.TypeMeta{Kind: "Service", APIVersion: "v1"}
+		svc.Spec.ClusterIP = "10.0.0.2"
+	})
+	s.ctx.AddPreviousObject(&coreV1.Service{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: service},
+		Spec:       coreV1.ServiceSpec{ClusterIP: "10.0.0.1"},
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				unchanged: {},
+				changed: {
+					{Message: "spec.selector changed, but the field is immutable: the Deployment has to be deleted and recreated to apply the change"},
+				},
+				added: {},
+				database: {
+					{Message: "spec.serviceName changed from \"database\" to \"database-headless\", but the field is immutable: the StatefulSet has to be deleted and recreated to apply the change"},
+				},
+				service: {
+					{Message: "spec.clusterIP changed from \"10.0.0.1\" to \"10.0.0.2\", but the field is immutable: the Service has to be deleted and recreated to apply the change"},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package initcontainerprobes
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/initcontainerprobes/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "init-container-probes"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Probes on Init Containers",
+		Key:         templateKey,
+		Description: "Flag init containers that specify probes but are not native sidecars",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				var results []diagnostic.Diagnostic
+				for _, container := range podSpec.InitContainers() {
+					for _, probe := range []struct {
+						kind  string
+						probe *v1.Probe
+					}{
+						{"liveness", container.LivenessProbe},
+						{"readiness", container.ReadinessProbe},
+						{"startup", container.StartupProbe},
+					} {
+						if probe.probe == nil {
+							continue
+						}
+						results = append(results, diagnostic.Diagnostic{
+							Message:   fmt.Sprintf("init container %q specifies a %s probe, but only native sidecars can have probes", container.Name, probe.kind),
+							Container: container.Name,
+						})
+					}
+				}
+				return results
+			}, nil
+		}),
+	})
+}
@@ -0,0 +1,70 @@
+package initcontainerprobes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/initcontainerprobes/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestInitContainerProbes(t *testing.T) {
+	suite.Run(t, new(InitContainerProbesTestSuite))
+}
+
+type InitContainerProbesTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *InitContainerProbesTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *InitContainerProbesTestSuite) addDeploymentWithInitContainers(name string, initContainers ...v1.Container) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.InitContainers = initContainers
+	})
+}
+
+func (s *InitContainerProbesTestSuite) TestProbes() {
+	const (
+		noProbes        = "no-probes"
+		probedInit      = "probed-init-container"
+		probedSidecar   = "probed-native-sidecar"
+		sidecarManifest = `
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: proxy
+        restartPolicy: Always
+`
+	)
+	probe := &v1.Probe{ProbeHandler: v1.ProbeHandler{Exec: &v1.ExecAction{Command: []string{"true"}}}}
+
+	s.addDeploymentWithInitContainers(noProbes, v1.Container{Name: "setup"})
+	s.addDeploymentWithInitContainers(probedInit, v1.Container{Name: "setup", ReadinessProbe: probe, StartupProbe: probe})
+	s.addDeploymentWithInitContainers(probedSidecar, v1.Container{Name: "proxy", LivenessProbe: probe, ReadinessProbe: probe})
+	s.ctx.SetRaw(probedSidecar, []byte(sidecarManifest))
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				probedInit: {
+					{Message: `init container "setup" specifies a readiness probe, but only native sidecars can have probes`},
+					{Message: `init container "setup" specifies a startup probe, but only native sidecars can have probes`},
+				},
+			},
+			ExpectInstantiationError: false,
+		},
+	})
+}
@@ -1,11 +1,19 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 
-	// list of regular expressions specifying pattern(s) for container images that will be blocked. */
+	// list of matchers specifying pattern(s) for container images that will be blocked. */
+	// +matcher
 	BlockList []string
 
-	// list of regular expressions specifying pattern(s) for container images that will be allowed.
+	// list of matchers specifying pattern(s) for container images that will be allowed.
+	// +matcher
 	AllowList []string
+
+	util.ContainerFilterParams
 }
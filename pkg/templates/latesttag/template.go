@@ -2,12 +2,12 @@ package latesttag
 
 import (
 	"fmt"
-	"regexp"
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/latesttag/internal/params"
@@ -31,34 +31,38 @@ func init() {
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
 
-			blockedRegexes := make([]*regexp.Regexp, 0, len(p.BlockList))
+			blockedMatchers := make([]func(string) bool, 0, len(p.BlockList))
 			for _, res := range p.BlockList {
-				rg, err := regexp.Compile(res)
+				m, err := matcher.ForString(res)
 				if err != nil {
-					return nil, errors.Wrapf(err, "invalid regex %s", res)
+					return nil, errors.Wrapf(err, "invalid matcher %s", res)
 				}
-				blockedRegexes = append(blockedRegexes, rg)
+				blockedMatchers = append(blockedMatchers, m)
 			}
 
-			allowedRegexes := make([]*regexp.Regexp, 0, len(p.AllowList))
+			allowedMatchers := make([]func(string) bool, 0, len(p.AllowList))
 			for _, res := range p.AllowList {
-				rg, err := regexp.Compile(res)
+				m, err := matcher.ForString(res)
 				if err != nil {
-					return nil, errors.Wrapf(err, "invalid regex %s", res)
+					return nil, errors.Wrapf(err, "invalid matcher %s", res)
 				}
-				allowedRegexes = append(allowedRegexes, rg)
+				allowedMatchers = append(allowedMatchers, m)
 			}
 
-			if len(blockedRegexes) > 0 && len(allowedRegexes) > 0 {
+			if len(blockedMatchers) > 0 && len(allowedMatchers) > 0 {
 				err := fmt.Errorf("check has both \"allowList\" & \"blockList\" parameter's values set")
 				return nil, errors.Wrapf(err, "only one of the paramater lists can be used at a time")
 			}
 
-			return util.PerContainerCheck(func(container *v1.Container) (results []diagnostic.Diagnostic) {
-				if len(blockedRegexes) > 0 && isInList(blockedRegexes, container.Image) {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that are not blocked by the `BlockList` criteria : %q", container.Name, container.Image, blockedRegexes)})
-				} else if len(allowedRegexes) > 0 && !isInList(allowedRegexes, container.Image) {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that satisfies the `AllowList` criteria : %q", container.Name, container.Image, allowedRegexes)})
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) (results []diagnostic.Diagnostic) {
+				if len(blockedMatchers) > 0 && isInList(blockedMatchers, container.Image) {
+					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that are not blocked by the `BlockList` criteria : %q", container.Name, container.Image, p.BlockList), FieldPath: "image"})
+				} else if len(allowedMatchers) > 0 && !isInList(allowedMatchers, container.Image) {
+					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that satisfies the `AllowList` criteria : %q", container.Name, container.Image, p.AllowList), FieldPath: "image"})
 				}
 				return results
 			}), nil
@@ -67,9 +71,9 @@ func init() {
 }
 
 // isInList returns true if a match found in the list for the given name
-func isInList(regexlist []*regexp.Regexp, name string) bool {
-	for _, regex := range regexlist {
-		if regex.MatchString(name) {
+func isInList(matchers []func(string) bool, name string) bool {
+	for _, m := range matchers {
+		if m(name) {
 			return true
 		}
 	}
@@ -1,5 +1,9 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 
@@ -18,4 +22,6 @@ type Params struct {
 	// a number of MB.
 	// If not specified, it is treated as "no upper bound".
 	UpperBoundMB *int `json:"upperBoundMB"`
+
+	util.ContainerFilterParams
 }
@@ -3,7 +3,6 @@ package memoryrequirements
 import (
 	"fmt"
 
-	"golang.stackrox.io/kube-linter/internal/pointers"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
@@ -15,14 +14,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
-const (
-	bytesInMB = 1024 * 1024
-)
-
-func process(results *[]diagnostic.Diagnostic, containerName, requirementsType string, quantity *resource.Quantity, lowerBoundBytes int, upperBoundBytes *int) {
-	if util.ValueInRange(int(quantity.Value()), lowerBoundBytes, upperBoundBytes) {
+func process(results *[]diagnostic.Diagnostic, containerName, requirementsType, fieldPath string, quantity *resource.Quantity, lowerBoundMB int, upperBoundMB *int) {
+	if util.MemoryInRange(quantity, lowerBoundMB, upperBoundMB) {
 		*results = append(*results, diagnostic.Diagnostic{
-			Message: fmt.Sprintf("container %q has memory %s %s", containerName, requirementsType, quantity),
+			Message:   fmt.Sprintf("container %q has memory %s %s", containerName, requirementsType, quantity),
+			FieldPath: fieldPath,
 		})
 	}
 }
@@ -38,18 +34,17 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
-			lowerBoundBytes := p.LowerBoundMB * bytesInMB
-			var upperBoundBytes *int
-			if p.UpperBoundMB != nil {
-				upperBoundBytes = pointers.Int((*p.UpperBoundMB) * bytesInMB)
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
 			}
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
 				if p.RequirementsType == "request" || p.RequirementsType == "any" {
-					process(&results, container.Name, "request", container.Resources.Requests.Memory(), lowerBoundBytes, upperBoundBytes)
+					process(&results, container.Name, "request", "resources.requests.memory", container.Resources.Requests.Memory(), p.LowerBoundMB, p.UpperBoundMB)
 				}
 				if p.RequirementsType == "limit" || p.RequirementsType == "any" {
-					process(&results, container.Name, "limit", container.Resources.Limits.Memory(), lowerBoundBytes, upperBoundBytes)
+					process(&results, container.Name, "limit", "resources.limits.memory", container.Resources.Limits.Memory(), p.LowerBoundMB, p.UpperBoundMB)
 				}
 				return results
 			}), nil
@@ -0,0 +1,60 @@
+package nativesidecarordering
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/nativesidecarordering/internal/params"
+)
+
+const (
+	templateKey = "native-sidecar-ordering"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Native Sidecar Ordering",
+		Key:         templateKey,
+		Description: "Flag native sidecars that are declared after init containers, which then run without the sidecar",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				var (
+					results            []diagnostic.Diagnostic
+					firstInitContainer string
+				)
+				// Init containers start in the order they are declared, and regular init containers
+				// run to completion before the next one starts.
+				for _, container := range podSpec.PodSpec.InitContainers {
+					if !podSpec.IsNativeSidecar(container.Name) {
+						if firstInitContainer == "" {
+							firstInitContainer = container.Name
+						}
+						continue
+					}
+					if firstInitContainer != "" {
+						results = append(results, diagnostic.Diagnostic{
+							Message:   fmt.Sprintf("native sidecar %q is declared after init container %q, which runs before the sidecar starts", container.Name, firstInitContainer),
+							Container: container.Name,
+						})
+					}
+				}
+				return results
+			}, nil
+		}),
+	})
+}
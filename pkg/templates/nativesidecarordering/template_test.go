@@ -0,0 +1,73 @@
+package nativesidecarordering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/nativesidecarordering/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNativeSidecarOrdering(t *testing.T) {
+	suite.Run(t, new(NativeSidecarOrderingTestSuite))
+}
+
+type NativeSidecarOrderingTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *NativeSidecarOrderingTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *NativeSidecarOrderingTestSuite) addDeploymentWithInitContainers(name, raw string, initContainerNames ...string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		for _, containerName := range initContainerNames {
+			deployment.Spec.Template.Spec.InitContainers = append(deployment.Spec.Template.Spec.InitContainers, v1.Container{Name: containerName})
+		}
+	})
+	s.ctx.SetRaw(name, []byte(raw))
+}
+
+func (s *NativeSidecarOrderingTestSuite) TestOrdering() {
+	const (
+		sidecarFirst = "sidecar-first"
+		sidecarLast  = "sidecar-last"
+		noSidecars   = "no-sidecars"
+		manifest     = `
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: proxy
+        restartPolicy: Always
+      - name: logs
+        restartPolicy: Always
+`
+	)
+
+	s.addDeploymentWithInitContainers(sidecarFirst, manifest, "proxy", "logs", "migrate")
+	s.addDeploymentWithInitContainers(sidecarLast, manifest, "migrate", "proxy", "setup", "logs")
+	s.addDeploymentWithInitContainers(noSidecars, "", "migrate", "setup")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				sidecarLast: {
+					{Message: `native sidecar "proxy" is declared after init container "migrate", which runs before the sidecar starts`},
+					{Message: `native sidecar "logs" is declared after init container "migrate", which runs before the sidecar starts`},
+				},
+			},
+			ExpectInstantiationError: false,
+		},
+	})
+}
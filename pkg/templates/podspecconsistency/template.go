@@ -0,0 +1,227 @@
+package podspecconsistency
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/podspecconsistency/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "pod-spec-consistency"
+)
+
+var (
+	// Fields supported by the downward API in environment variables and in downwardAPI volumes.
+	// See https://kubernetes.io/docs/concepts/workloads/pods/downward-api/#available-fields.
+	envFieldPaths = set.NewFrozenStringSet(
+		"metadata.name", "metadata.namespace", "metadata.uid",
+		"spec.nodeName", "spec.serviceAccountName",
+		"status.hostIP", "status.hostIPs", "status.podIP", "status.podIPs",
+	)
+	volumeFieldPaths = set.NewFrozenStringSet(
+		"metadata.name", "metadata.namespace", "metadata.uid",
+		"metadata.labels", "metadata.annotations",
+	)
+
+	labelOrAnnotationFieldPathRegex = regexp.MustCompile(`^metadata\.(labels|annotations)\['[^']+'\]$`)
+	serviceLinkVarRegex             = regexp.MustCompile(`_(SERVICE_HOST|SERVICE_PORT|PORT)(_|$)`)
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Pod Spec Consistency",
+		Key:         templateKey,
+		Description: "Flag pod specs that are inconsistent, e.g. containers mounting volumes that are not defined, which the API server rejects or which don't behave as intended",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				return checkPodSpec(podSpec)
+			}, nil
+		}),
+	})
+}
+
+type namedContainer struct {
+	field     string
+	container *v1.Container
+}
+
+func checkPodSpec(podSpec customtypes.PodSpec) []diagnostic.Diagnostic {
+	var results []diagnostic.Diagnostic
+
+	volumes := make(map[string]int, len(podSpec.Volumes))
+	for i, volume := range podSpec.Volumes {
+		if first, ok := volumes[volume.Name]; ok {
+			results = append(results, diagnostic.Diagnostic{
+				Message: fmt.Sprintf("volumes[%d].name %q is also used by volumes[%d]", i, volume.Name, first),
+			})
+			continue
+		}
+		volumes[volume.Name] = i
+		if volume.DownwardAPI == nil {
+			continue
+		}
+		for j, item := range volume.DownwardAPI.Items {
+			if item.FieldRef != nil && !isSupportedFieldPath(volumeFieldPaths, item.FieldRef.FieldPath) {
+				results = append(results, diagnostic.Diagnostic{
+					Message: fmt.Sprintf("volumes[%d].downwardAPI.items[%d].fieldRef.fieldPath %q is not supported by the downward API", i, j, item.FieldRef.FieldPath),
+				})
+			}
+		}
+	}
+
+	// Container names must be unique across init, regular and ephemeral containers.
+	containerNames := make(map[string]string)
+	for _, c := range allContainers(podSpec) {
+		field := fmt.Sprintf("%s.name", c.field)
+		if first, ok := containerNames[c.container.Name]; ok {
+			results = append(results, diagnostic.Diagnostic{
+				Message:   fmt.Sprintf("%s %q is also used by %s", field, c.container.Name, first),
+				Container: c.container.Name,
+			})
+		} else {
+			containerNames[c.container.Name] = field
+		}
+		for _, d := range checkContainer(c.container, volumes) {
+			d.Container = c.container.Name
+			d.Message = fmt.Sprintf("container %q: %s", c.container.Name, d.Message)
+			results = append(results, d)
+		}
+	}
+	return results
+}
+
+func allContainers(podSpec customtypes.PodSpec) []namedContainer {
+	var containers []namedContainer
+	for i := range podSpec.PodSpec.InitContainers {
+		containers = append(containers, namedContainer{field: fmt.Sprintf("initContainers[%d]", i), container: &podSpec.PodSpec.InitContainers[i]})
+	}
+	for i := range podSpec.PodSpec.Containers {
+		containers = append(containers, namedContainer{field: fmt.Sprintf("containers[%d]", i), container: &podSpec.PodSpec.Containers[i]})
+	}
+	for i := range podSpec.PodSpec.EphemeralContainers {
+		container := v1.Container(podSpec.PodSpec.EphemeralContainers[i].EphemeralContainerCommon)
+		containers = append(containers, namedContainer{field: fmt.Sprintf("ephemeralContainers[%d]", i), container: &container})
+	}
+	return containers
+}
+
+func checkContainer(container *v1.Container, volumes map[string]int) []diagnostic.Diagnostic {
+	var results []diagnostic.Diagnostic
+	report := func(format string, args ...interface{}) {
+		results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf(format, args...)})
+	}
+
+	mountPaths := make(map[string]int, len(container.VolumeMounts))
+	for i, mount := range container.VolumeMounts {
+		if _, ok := volumes[mount.Name]; !ok {
+			report("volumeMounts[%d].name refers to volume %q, which is not defined", i, mount.Name)
+		}
+		if first, ok := mountPaths[mount.MountPath]; ok {
+			report("volumeMounts[%d].mountPath %q is also used by volumeMounts[%d]", i, mount.MountPath, first)
+		} else {
+			mountPaths[mount.MountPath] = i
+		}
+		if mount.SubPath != "" && mount.SubPathExpr != "" {
+			report("volumeMounts[%d] sets both subPath and subPathExpr", i)
+		}
+	}
+
+	portNames := make(map[string]int, len(container.Ports))
+	portNumbers := make(map[string]int, len(container.Ports))
+	for i, port := range container.Ports {
+		if port.Name != "" {
+			if first, ok := portNames[port.Name]; ok {
+				report("ports[%d].name %q is also used by ports[%d]", i, port.Name, first)
+			} else {
+				portNames[port.Name] = i
+			}
+		}
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = v1.ProtocolTCP
+		}
+		number := fmt.Sprintf("%d/%s", port.ContainerPort, protocol)
+		if first, ok := portNumbers[number]; ok {
+			report("ports[%d].containerPort %s is also declared by ports[%d]", i, number, first)
+		} else {
+			portNumbers[number] = i
+		}
+	}
+
+	// Variables from envFrom are defined before the ones from env, but their names are not known offline.
+	definedVars := set.NewStringSet()
+	for i, envVar := range container.Env {
+		if envVar.ValueFrom != nil && envVar.ValueFrom.FieldRef != nil && !isSupportedFieldPath(envFieldPaths, envVar.ValueFrom.FieldRef.FieldPath) {
+			report("env[%d].valueFrom.fieldRef.fieldPath %q is not supported by the downward API", i, envVar.ValueFrom.FieldRef.FieldPath)
+		}
+		for _, ref := range varReferences(envVar.Value) {
+			if ref == "" || definedVars.Contains(ref) {
+				continue
+			}
+			if definedLater(container.Env[i+1:], ref) {
+				report("env[%d].value refers to $(%s), which is only defined later", i, ref)
+			} else if len(container.EnvFrom) == 0 && !serviceLinkVarRegex.MatchString(ref) {
+				report("env[%d].value refers to $(%s), which is not defined", i, ref)
+			}
+		}
+		definedVars.Add(envVar.Name)
+	}
+	return results
+}
+
+func isSupportedFieldPath(supported set.FrozenStringSet, fieldPath string) bool {
+	return supported.Contains(fieldPath) || labelOrAnnotationFieldPathRegex.MatchString(fieldPath)
+}
+
+func definedLater(envVars []v1.EnvVar, name string) bool {
+	for _, envVar := range envVars {
+		if envVar.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// varReferences returns the names of the variables referenced as $(VAR) in the given value.
+// $$ escapes a $, as in Kubernetes.
+func varReferences(value string) []string {
+	var refs []string
+	for i := 0; i < len(value)-1; i++ {
+		if value[i] != '$' {
+			continue
+		}
+		switch value[i+1] {
+		case '$':
+			i++
+		case '(':
+			end := strings.IndexByte(value[i+2:], ')')
+			if end < 0 {
+				return refs
+			}
+			refs = append(refs, value[i+2:i+2+end])
+			i += 2 + end
+		}
+	}
+	return refs
+}
@@ -0,0 +1,135 @@
+package podspecconsistency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/podspecconsistency/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodSpecConsistency(t *testing.T) {
+	suite.Run(t, new(PodSpecConsistencyTestSuite))
+}
+
+type PodSpecConsistencyTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *PodSpecConsistencyTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *PodSpecConsistencyTestSuite) addDeploymentWithPodSpec(name string, podSpec v1.PodSpec) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec = podSpec
+	})
+}
+
+func (s *PodSpecConsistencyTestSuite) TestConsistent() {
+	const consistent = "consistent"
+	s.addDeploymentWithPodSpec(consistent, v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "config"},
+			{Name: "podinfo", VolumeSource: v1.VolumeSource{DownwardAPI: &v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "labels", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+				},
+			}}},
+		},
+		InitContainers: []v1.Container{{Name: "setup"}},
+		Containers: []v1.Container{{
+			Name: "app",
+			VolumeMounts: []v1.VolumeMount{
+				{Name: "config", MountPath: "/etc/app"},
+				{Name: "podinfo", MountPath: "/etc/podinfo"},
+			},
+			Ports: []v1.ContainerPort{
+				{Name: "http", ContainerPort: 8080},
+				{Name: "dns", ContainerPort: 53, Protocol: v1.ProtocolUDP},
+				{Name: "dns-tcp", ContainerPort: 53},
+			},
+			Env: []v1.EnvVar{
+				{Name: "NODE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+				{Name: "TEAM", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['team']"}}},
+				{Name: "URL", Value: "http://$(NODE):$(DB_SERVICE_PORT)/$$(LITERAL)"},
+			},
+		}},
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				consistent: {},
+			},
+		},
+	})
+}
+
+func (s *PodSpecConsistencyTestSuite) TestInconsistent() {
+	const inconsistent = "inconsistent"
+	s.addDeploymentWithPodSpec(inconsistent, v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "config"},
+			{Name: "config"},
+			{Name: "podinfo", VolumeSource: v1.VolumeSource{DownwardAPI: &v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "node", FieldRef: &v1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+				},
+			}}},
+		},
+		InitContainers: []v1.Container{{Name: "app"}},
+		Containers: []v1.Container{{
+			Name: "app",
+			VolumeMounts: []v1.VolumeMount{
+				{Name: "config", MountPath: "/etc/app", SubPath: "app.yaml", SubPathExpr: "$(POD)"},
+				{Name: "data", MountPath: "/etc/app"},
+			},
+			Ports: []v1.ContainerPort{
+				{Name: "http", ContainerPort: 8080},
+				{Name: "http", ContainerPort: 8080, Protocol: v1.ProtocolTCP},
+			},
+			Env: []v1.EnvVar{
+				{Name: "IP", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.phase"}}},
+				{Name: "URL", Value: "http://$(HOST):$(PORT_NUMBER)"},
+				{Name: "HOST", Value: "localhost"},
+			},
+		}},
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				inconsistent: {
+					{Message: `volumes[1].name "config" is also used by volumes[0]`},
+					{Message: `volumes[2].downwardAPI.items[0].fieldRef.fieldPath "spec.nodeName" is not supported by the downward API`},
+					{Message: `containers[0].name "app" is also used by initContainers[0].name`},
+					{Message: `container "app": volumeMounts[0] sets both subPath and subPathExpr`},
+					{Message: `container "app": volumeMounts[1].name refers to volume "data", which is not defined`},
+					{Message: `container "app": volumeMounts[1].mountPath "/etc/app" is also used by volumeMounts[0]`},
+					{Message: `container "app": ports[1].name "http" is also used by ports[0]`},
+					{Message: `container "app": ports[1].containerPort 8080/TCP is also declared by ports[0]`},
+					{Message: `container "app": env[0].valueFrom.fieldRef.fieldPath "status.phase" is not supported by the downward API`},
+					{Message: `container "app": env[1].value refers to $(HOST), which is only defined later`},
+					{Message: `container "app": env[1].value refers to $(PORT_NUMBER), which is not defined`},
+				},
+			},
+		},
+	})
+}
+
+func TestVarReferences(t *testing.T) {
+	assert.Equal(t, []string{"A", "B_C"}, varReferences("$(A)-$$(ESCAPED)-$(B_C)-$(UNTERMINATED"))
+	assert.Empty(t, varReferences("no references, $ or $$"))
+}
@@ -1,5 +1,9 @@
 package params
 
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
 // Params represents the params accepted by this template.
 type Params struct {
 
@@ -7,5 +11,8 @@ type Params struct {
 	Port int
 
 	// The protocol
+	// +matcher
 	Protocol string
+
+	util.ContainerFilterParams
 }
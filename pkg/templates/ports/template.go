@@ -31,9 +31,13 @@ func init() {
 			if err != nil {
 				return nil, errors.Wrap(err, "invalid protocol")
 			}
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, port := range container.Ports {
+				for i, port := range container.Ports {
 					// The k8s protocol defaults to TCP even if not set in the YAML.
 					protocol := string(port.Protocol)
 					if protocol == "" {
@@ -43,6 +47,7 @@ func init() {
 						results = append(results, diagnostic.Diagnostic{
 							Message: fmt.Sprintf("port %d and protocol %s in container %q found",
 								port.ContainerPort, protocol, container.Name),
+							FieldPath: fmt.Sprintf("ports[%d].containerPort", i),
 						})
 					}
 				}
@@ -23,11 +23,18 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				if securityContext := container.SecurityContext; securityContext != nil {
 					if securityContext.Privileged != nil && *securityContext.Privileged {
-						return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is privileged", container.Name)}}
+						return []diagnostic.Diagnostic{{
+							Message:   fmt.Sprintf("container %q is privileged", container.Name),
+							FieldPath: "securityContext.privileged",
+						}}
 					}
 				}
 				return nil
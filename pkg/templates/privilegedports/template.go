@@ -23,13 +23,18 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, port := range container.Ports {
+				for i, port := range container.Ports {
 					if int(port.ContainerPort) > 0 && int(port.ContainerPort) < 1024 {
 						results = append(results, diagnostic.Diagnostic{
-							Message: fmt.Sprintf("port %d is mapped in container %q.", port.ContainerPort, container.Name),
+							Message:   fmt.Sprintf("port %d is mapped in container %q.", port.ContainerPort, container.Name),
+							FieldPath: fmt.Sprintf("ports[%d].containerPort", i),
 						})
 					}
 				}
@@ -27,22 +27,35 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				securityContext := container.SecurityContext
 				if securityContext == nil {
 					return nil
 				}
 				if securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has AllowPrivilegeEscalation set to true.", container.Name)}}
+					return []diagnostic.Diagnostic{{
+						Message:   fmt.Sprintf("container %q has AllowPrivilegeEscalation set to true.", container.Name),
+						FieldPath: "securityContext.allowPrivilegeEscalation",
+					}}
 				}
 				if securityContext.Privileged != nil && *securityContext.Privileged {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is Privileged and allows privilege escalation.", container.Name)}}
+					return []diagnostic.Diagnostic{{
+						Message:   fmt.Sprintf("container %q is Privileged and allows privilege escalation.", container.Name),
+						FieldPath: "securityContext.privileged",
+					}}
 				}
 				if securityContext.Capabilities != nil {
-					for _, cap := range securityContext.Capabilities.Add {
+					for i, cap := range securityContext.Capabilities.Add {
 						if cap == v1.Capability(sysAdminCapability) {
-							return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has SYS_ADMIN capability and allows privilege escalation.", container.Name)}}
+							return []diagnostic.Diagnostic{{
+								Message:   fmt.Sprintf("container %q has SYS_ADMIN capability and allows privilege escalation.", container.Name),
+								FieldPath: fmt.Sprintf("securityContext.capabilities.add[%d]", i),
+							}}
 						}
 					}
 				}
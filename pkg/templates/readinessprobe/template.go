@@ -23,8 +23,12 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return util.PerNonInitContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerNonInitContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				if container.ReadinessProbe == nil {
 					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q does not specify a readiness probe", container.Name)}}
 				}
@@ -23,13 +23,18 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, envVar := range container.Env {
+				for i, envVar := range container.Env {
 					if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
 						results = append(results, diagnostic.Diagnostic{
-							Message: fmt.Sprintf("environment variable %q in container %q uses SecretKeyRef", envVar.Name, container.Name),
+							Message:   fmt.Sprintf("environment variable %q in container %q uses SecretKeyRef", envVar.Name, container.Name),
+							FieldPath: fmt.Sprintf("env[%d].valueFrom.secretKeyRef", i),
 						})
 					}
 				}
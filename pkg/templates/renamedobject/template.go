@@ -0,0 +1,92 @@
+package renamedobject
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/renamedobject/internal/params"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	templateKey = "renamed-object"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Renamed Objects",
+		Key:         templateKey,
+		Description: "Flag objects that appear to be objects of the previous revision of the manifests under a new name, which deletes the old object and creates a new one instead of updating it",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				transitionCtx, ok := lintCtx.(lintcontext.TransitionContext)
+				if !ok {
+					return nil
+				}
+				if _, found := transitionCtx.PreviousObject(object); found {
+					return nil
+				}
+				key := lintcontext.KeyOf(object.K8sObject)
+				for _, removed := range transitionCtx.RemovedObjects() {
+					removedKey := lintcontext.KeyOf(removed.K8sObject)
+					if removedKey.Group != key.Group || removedKey.Kind != key.Kind || removedKey.Namespace != key.Namespace {
+						continue
+					}
+					if !sameIdentity(removed.K8sObject, object.K8sObject) {
+						continue
+					}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("object appears to be %s %q of the previous revision under a new name: "+
+							"applying the change deletes %q and creates a new object, rather than updating it", key.Kind, removedKey.Name, removedKey.Name),
+						RelatedObjects: []lintcontext.Object{removed},
+					}}
+				}
+				return nil
+			}, nil
+		}),
+	})
+}
+
+// sameIdentity returns whether two objects of the same kind are likely the same object: workloads and services that
+// select the same pods, or other objects with the same contents apart from their name.
+func sameIdentity(previous, current k8sutil.Object) bool {
+	if currentService, ok := current.(*coreV1.Service); ok {
+		previousService, ok := previous.(*coreV1.Service)
+		return ok && len(currentService.Spec.Selector) > 0 &&
+			equality.Semantic.DeepEqual(previousService.Spec.Selector, currentService.Spec.Selector)
+	}
+	if currentSelector, found := extract.Selector(current); found {
+		previousSelector, _ := extract.Selector(previous)
+		return !isEmpty(currentSelector) && equality.Semantic.DeepEqual(previousSelector, currentSelector)
+	}
+
+	previousCopy, ok := previous.DeepCopyObject().(k8sutil.Object)
+	if !ok {
+		return false
+	}
+	currentCopy, ok := current.DeepCopyObject().(k8sutil.Object)
+	if !ok {
+		return false
+	}
+	previousCopy.SetName("")
+	currentCopy.SetName("")
+	return equality.Semantic.DeepEqual(previousCopy, currentCopy)
+}
+
+func isEmpty(selector *metaV1.LabelSelector) bool {
+	return selector == nil || len(selector.MatchLabels)+len(selector.MatchExpressions) == 0
+}
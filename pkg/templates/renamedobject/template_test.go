@@ -0,0 +1,82 @@
+package renamedobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/renamedobject/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenamedObject(t *testing.T) {
+	suite.Run(t, new(RenamedObjectTestSuite))
+}
+
+type RenamedObjectTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *RenamedObjectTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func deployment(name, app string) *appsV1.Deployment {
+	return &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Spec:       appsV1.DeploymentSpec{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": app}}},
+	}
+}
+
+func configMap(name, value string) *coreV1.ConfigMap {
+	return &coreV1.ConfigMap{
+		TypeMeta:   metaV1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Data:       map[string]string{"key": value},
+	}
+}
+
+func (s *RenamedObjectTestSuite) TestRenamedObjects() {
+	const (
+		kept            = "kept"
+		renamed         = "backend-v2"
+		added           = "added"
+		renamedConfig   = "config-v2"
+		rewrittenConfig = "other-config-v2"
+	)
+	s.ctx.AddObject(kept, deployment(kept, kept))
+	s.ctx.AddPreviousObject(deployment(kept, kept))
+	s.ctx.AddObject(renamed, deployment(renamed, "backend"))
+	s.ctx.AddPreviousObject(deployment("backend", "backend"))
+	s.ctx.AddObject(added, deployment(added, added))
+
+	s.ctx.AddObject(renamedConfig, configMap(renamedConfig, "value"))
+	s.ctx.AddPreviousObject(configMap("config", "value"))
+	s.ctx.AddObject(rewrittenConfig, configMap(rewrittenConfig, "new value"))
+	s.ctx.AddPreviousObject(configMap("other-config", "old value"))
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				kept: {},
+				renamed: {
+					{Message: "object appears to be Deployment \"backend\" of the previous revision under a new name: applying the change deletes \"backend\" and creates a new object, rather than updating it"},
+				},
+				added: {},
+				renamedConfig: {
+					{Message: "object appears to be ConfigMap \"config\" of the previous revision under a new name: applying the change deletes \"config\" and creates a new object, rather than updating it"},
+				},
+				rewrittenConfig: {},
+			},
+		},
+	})
+}
@@ -5,8 +5,10 @@ type Params struct {
 
 	// Key of the required label.
 	// +required
+	// +matcher
 	Key string
 
 	// Value of the required label.
+	// +matcher
 	Value string
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	keyParamDesc = util.MustParseParameterDesc(`{
 	"Name": "key",
@@ -26,8 +28,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": true,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "Key",
 	"XXXIsPointer": false
 }
@@ -42,8 +43,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": false,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "Value",
 	"XXXIsPointer": false
 }
@@ -60,6 +60,12 @@ func (p *Params) Validate() error {
 	if p.Key == "" {
 		validationErrors = append(validationErrors, "required param key not found")
 	}
+	if err := matcher.Validate(p.Key, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param key has invalid value %q: %v", p.Key, err))
+	}
+	if err := matcher.Validate(p.Value, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param value has invalid value %q: %v", p.Value, err))
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
@@ -0,0 +1,29 @@
+package params
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// Memory requirements below this number of MB are reported, since they are likely
+	// missing a unit suffix, e.g. 512 instead of 512Mi.
+	// If not specified, it is treated as a minimum of zero.
+	MinMemoryMB int `json:"minMemoryMB"`
+
+	// CPU requirements above this number of cores are reported, since they are likely
+	// missing the milli suffix, e.g. 1000 instead of 1000m.
+	// If not specified, it is treated as "no maximum".
+	MaxCPUCores *int `json:"maxCPUCores"`
+
+	// The kind of suffixes that memory requirements must use: binary suffixes such as Mi and Gi,
+	// or decimal suffixes such as M and G. Use any to allow both.
+	// +enum=binary
+	// +enum=decimal
+	// +enum=any
+	// +required
+	MemorySuffixes string `json:"memorySuffixes"`
+
+	util.ContainerFilterParams
+}
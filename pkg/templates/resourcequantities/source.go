@@ -0,0 +1,74 @@
+package resourcequantities
+
+import (
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	v1 "k8s.io/api/core/v1"
+)
+
+// writtenQuantities are the requests and limits of the containers as written in the source, by container name,
+// requirements type and resource. The decoded quantities are canonicalized, e.g. 1000M becomes 1G, so whether
+// a quantity was written with a suffix can only be told from the source.
+type writtenQuantities map[string]map[string]map[v1.ResourceName]string
+
+// get returns the quantity of the resource as written in the source, if it is known.
+func (w writtenQuantities) get(containerName, requirementsType string, name v1.ResourceName) (string, bool) {
+	written, found := w[containerName][requirementsType][name]
+	return written, found
+}
+
+// writtenQuantitiesFromSource returns the quantities of the containers declared anywhere in the given source.
+func writtenQuantitiesFromSource(source []byte) writtenQuantities {
+	var doc interface{}
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return nil
+	}
+	quantities := make(writtenQuantities)
+	collectWrittenQuantities(doc, quantities)
+	return quantities
+}
+
+func collectWrittenQuantities(node interface{}, quantities writtenQuantities) {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			switch key {
+			case "containers", "initContainers", "ephemeralContainers":
+				containers, _ := value.([]interface{})
+				for _, container := range containers {
+					fields, _ := container.(map[string]interface{})
+					name, ok := fields["name"].(string)
+					resources, _ := fields["resources"].(map[string]interface{})
+					if ok && resources != nil {
+						quantities[name] = writtenRequirements(resources)
+					}
+				}
+			default:
+				collectWrittenQuantities(value, quantities)
+			}
+		}
+	case []interface{}:
+		for _, elem := range node {
+			collectWrittenQuantities(elem, quantities)
+		}
+	}
+}
+
+func writtenRequirements(resources map[string]interface{}) map[string]map[v1.ResourceName]string {
+	requirements := make(map[string]map[v1.ResourceName]string)
+	for _, requirementsType := range []string{requestType, limitType} {
+		list, _ := resources[requirementsType+"s"].(map[string]interface{})
+		written := make(map[v1.ResourceName]string, len(list))
+		for name, value := range list {
+			switch value := value.(type) {
+			case string:
+				written[v1.ResourceName(name)] = value
+			case float64:
+				written[v1.ResourceName(name)] = strconv.FormatFloat(value, 'f', -1, 64)
+			}
+		}
+		requirements[requirementsType] = written
+	}
+	return requirements
+}
@@ -0,0 +1,176 @@
+package resourcequantities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/resourcequantities/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	templateKey = "resource-quantities"
+
+	requestType = "request"
+	limitType   = "limit"
+)
+
+var (
+	decimalSuffixes = map[string]string{"k": "Ki", "M": "Mi", "G": "Gi", "T": "Ti", "P": "Pi", "E": "Ei"}
+	binarySuffixes  = map[string]string{"Ki": "k", "Mi": "M", "Gi": "G", "Ti": "T", "Pi": "P", "Ei": "E"}
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Resource Quantities",
+		Key:         templateKey,
+		Description: "Flag container resource requirements that are likely unit mistakes, such as memory with the milli suffix, CPU missing the milli suffix, requests above limits and fractional extended resources",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				written := writtenQuantitiesFromSource(object.Metadata.Raw)
+				return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
+					c := &containerChecker{params: &p, container: container, written: written}
+					return c.check()
+				})(lintCtx, object)
+			}, nil
+		}),
+	})
+}
+
+type containerChecker struct {
+	params    *params.Params
+	container *v1.Container
+	written   writtenQuantities
+
+	results []diagnostic.Diagnostic
+}
+
+// report reports a problem with the quantity of the given resource in the requests or the limits of the container.
+func (c *containerChecker) report(requirementsType string, name v1.ResourceName, message string) {
+	c.results = append(c.results, diagnostic.Diagnostic{
+		Message:   fmt.Sprintf("container %q has %s", c.container.Name, message),
+		FieldPath: fmt.Sprintf("resources.%ss.%s", requirementsType, name),
+	})
+}
+
+func (c *containerChecker) check() []diagnostic.Diagnostic {
+	for _, requirementsType := range []string{requestType, limitType} {
+		list := c.container.Resources.Requests
+		if requirementsType == limitType {
+			list = c.container.Resources.Limits
+		}
+		for _, name := range sortedNames(list) {
+			quantity := list[name]
+			c.checkQuantity(name, requirementsType, &quantity, c.writtenQuantity(requirementsType, name, &quantity))
+		}
+	}
+	for _, name := range sortedNames(c.container.Resources.Requests) {
+		request := c.container.Resources.Requests[name]
+		if limit, found := c.container.Resources.Limits[name]; found && request.Cmp(limit) > 0 {
+			c.report(requestType, name, fmt.Sprintf("%s request %s above its limit %s", name,
+				c.writtenQuantity(requestType, name, &request), c.writtenQuantity(limitType, name, &limit)))
+		}
+	}
+	return c.results
+}
+
+// writtenQuantity returns the quantity as written in the source, or its canonical form if the source is unknown.
+func (c *containerChecker) writtenQuantity(requirementsType string, name v1.ResourceName, quantity *resource.Quantity) string {
+	if written, found := c.written.get(c.container.Name, requirementsType, name); found {
+		return written
+	}
+	return quantity.String()
+}
+
+func (c *containerChecker) checkQuantity(name v1.ResourceName, requirementsType string, quantity *resource.Quantity, written string) {
+	suffix := suffixOf(written)
+	switch {
+	case name == v1.ResourceMemory:
+		if quantity.MilliValue()%1000 != 0 {
+			message := fmt.Sprintf("memory %s %s, which is a fraction of a byte", requirementsType, written)
+			if suffix == "m" {
+				message += ": the m suffix means milli, use Mi for mebibytes"
+			}
+			c.report(requirementsType, name, message)
+			return
+		}
+		if !util.MemoryInRange(quantity, c.params.MinMemoryMB, nil) {
+			message := fmt.Sprintf("memory %s %s, which is below %dMB", requirementsType, written, c.params.MinMemoryMB)
+			if suffix == "" {
+				message += fmt.Sprintf(": quantities without a suffix are bytes, use %sMi for mebibytes", written)
+			}
+			c.report(requirementsType, name, message)
+		}
+		switch c.params.MemorySuffixes {
+		case "binary":
+			if binary, isDecimal := decimalSuffixes[suffix]; isDecimal {
+				c.report(requirementsType, name, fmt.Sprintf("memory %s %s, which uses the decimal suffix %s instead of a binary suffix such as %s", requirementsType, written, suffix, binary))
+			}
+		case "decimal":
+			if decimal, isBinary := binarySuffixes[suffix]; isBinary {
+				c.report(requirementsType, name, fmt.Sprintf("memory %s %s, which uses the binary suffix %s instead of a decimal suffix such as %s", requirementsType, written, suffix, decimal))
+			}
+		}
+	case name == v1.ResourceCPU:
+		if c.params.MaxCPUCores != nil && !util.CPUInRange(quantity, math.MinInt, pointers.Int(*c.params.MaxCPUCores*1000)) {
+			message := fmt.Sprintf("cpu %s %s, which is above %d cores", requirementsType, written, *c.params.MaxCPUCores)
+			if suffix == "" {
+				message += fmt.Sprintf(": quantities without a suffix are cores, use %sm for millicores", written)
+			}
+			c.report(requirementsType, name, message)
+		}
+	case isExtendedResource(name):
+		if quantity.MilliValue()%1000 != 0 {
+			c.report(requirementsType, name, fmt.Sprintf("%s %s %s, which is not an integer as extended resources require", name, requirementsType, written))
+		}
+	}
+}
+
+// suffixOf returns the suffix of a quantity as written, e.g. Mi for 512Mi, or an empty string if it has none.
+// Exponents, e.g. e9 for 1e9, are not suffixes.
+func suffixOf(written string) string {
+	suffix := strings.TrimLeft(written, "+-0123456789.")
+	if len(suffix) > 1 && (suffix[0] == 'e' || suffix[0] == 'E') && strings.ContainsAny(suffix[1:2], "+-0123456789") {
+		return ""
+	}
+	return suffix
+}
+
+// isExtendedResource returns whether the resource is an extended resource, e.g. nvidia.com/gpu, which are
+// fully-qualified and outside the kubernetes.io domain.
+func isExtendedResource(name v1.ResourceName) bool {
+	return strings.Contains(string(name), "/") &&
+		!strings.HasPrefix(string(name), v1.ResourceDefaultNamespacePrefix) &&
+		!strings.HasPrefix(string(name), v1.DefaultResourceRequestsPrefix)
+}
+
+func sortedNames(list v1.ResourceList) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,150 @@
+package resourcequantities
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/resourcequantities/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	deploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: setup
+        resources:
+          requests:
+            memory: 512
+      containers:
+      - name: app
+        resources:
+          requests:
+            cpu: 1000
+            memory: 512m
+            nvidia.com/gpu: 500m
+          limits:
+            cpu: 500m
+            memory: 1G
+            nvidia.com/gpu: 1
+      - name: proxy
+        resources:
+          requests:
+            cpu: 100m
+            memory: 64Mi
+          limits:
+            memory: 1000M
+`
+)
+
+func TestResourceQuantities(t *testing.T) {
+	suite.Run(t, new(ResourceQuantitiesTestSuite))
+}
+
+type ResourceQuantitiesTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ResourceQuantitiesTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *ResourceQuantitiesTestSuite) addObjects() {
+	var deployment appsV1.Deployment
+	s.Require().NoError(yaml.Unmarshal([]byte(deploymentYAML), &deployment))
+	s.ctx.AddObject("app", &deployment)
+	s.ctx.SetRaw("app", []byte(deploymentYAML))
+
+	// Without the source, the quantities are reported in their canonical form.
+	s.ctx.AddObject("generated", &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "generated"},
+		Spec: appsV1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{
+						Name: "app",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1500M")},
+							Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					}},
+				},
+			},
+		},
+	})
+}
+
+func (s *ResourceQuantitiesTestSuite) TestDefaults() {
+	s.addObjects()
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: `container "app" has memory request 512m, which is a fraction of a byte: the m suffix means milli, use Mi for mebibytes`},
+					{Message: `container "app" has nvidia.com/gpu request 500m, which is not an integer as extended resources require`},
+					{Message: `container "app" has cpu request 1000 above its limit 500m`},
+				},
+				"generated": {
+					{Message: `container "app" has memory request 1500M above its limit 1Gi`},
+				},
+			},
+		},
+	})
+}
+
+func (s *ResourceQuantitiesTestSuite) TestBoundsAndSuffixes() {
+	s.addObjects()
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{MinMemoryMB: 4, MaxCPUCores: pointers.Int(64), MemorySuffixes: "binary"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: `container "setup" has memory request 512, which is below 4MB: quantities without a suffix are bytes, use 512Mi for mebibytes`},
+					{Message: `container "app" has cpu request 1000, which is above 64 cores: quantities without a suffix are cores, use 1000m for millicores`},
+					{Message: `container "app" has memory request 512m, which is a fraction of a byte: the m suffix means milli, use Mi for mebibytes`},
+					{Message: `container "app" has nvidia.com/gpu request 500m, which is not an integer as extended resources require`},
+					{Message: `container "app" has memory limit 1G, which uses the decimal suffix G instead of a binary suffix such as Gi`},
+					{Message: `container "app" has cpu request 1000 above its limit 500m`},
+					{Message: `container "proxy" has memory limit 1000M, which uses the decimal suffix M instead of a binary suffix such as Mi`},
+				},
+				"generated": {
+					{Message: `container "app" has memory request 1500M, which uses the decimal suffix M instead of a binary suffix such as Mi`},
+					{Message: `container "app" has memory request 1500M above its limit 1Gi`},
+				},
+			},
+		},
+		{
+			Param: params.Params{MemorySuffixes: "decimal"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: `container "app" has memory request 512m, which is a fraction of a byte: the m suffix means milli, use Mi for mebibytes`},
+					{Message: `container "app" has nvidia.com/gpu request 500m, which is not an integer as extended resources require`},
+					{Message: `container "app" has cpu request 1000 above its limit 500m`},
+					{Message: `container "proxy" has memory request 64Mi, which uses the binary suffix Mi instead of a decimal suffix such as M`},
+				},
+				"generated": {
+					{Message: `container "app" has memory limit 1Gi, which uses the binary suffix Gi instead of a decimal suffix such as G`},
+					{Message: `container "app" has memory request 1500M above its limit 1Gi`},
+				},
+			},
+		},
+	})
+}
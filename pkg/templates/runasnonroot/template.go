@@ -11,6 +11,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/runasnonroot/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -50,6 +51,7 @@ func init() {
 				if !found {
 					return nil
 				}
+				containerPaths := util.ContainerPaths(object.K8sObject)
 				var results []diagnostic.Diagnostic
 				for _, container := range podSpec.AllContainers() {
 					runAsUser := effectiveRunAsUser(podSpec.SecurityContext, container.SecurityContext)
@@ -62,12 +64,18 @@ func init() {
 						// runAsNonRoot set, but runAsUser set to 0. This will result in a runtime failure.
 						if runAsUser != nil && *runAsUser == 0 {
 							results = append(results, diagnostic.Diagnostic{
-								Message: fmt.Sprintf("container %q is set to runAsNonRoot, but runAsUser set to %d", container.Name, *runAsUser),
+								Message:   fmt.Sprintf("container %q is set to runAsNonRoot, but runAsUser set to %d", container.Name, *runAsUser),
+								Container: container.Name,
+								FieldPath: containerPaths[container.Name],
 							})
 						}
 						continue
 					}
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("container %q is not set to runAsNonRoot", container.Name)})
+					results = append(results, diagnostic.Diagnostic{
+						Message:   fmt.Sprintf("container %q is not set to runAsNonRoot", container.Name),
+						Container: container.Name,
+						FieldPath: containerPaths[container.Name],
+					})
 				}
 				return results
 			}, nil
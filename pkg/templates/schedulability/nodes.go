@@ -0,0 +1,197 @@
+package schedulability
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.stackrox.io/kube-linter/pkg/config"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	defaultAllocatablePods = 110
+)
+
+// unlimitedResources are the resources that nodes have an unlimited amount of if their pool doesn't list them.
+var unlimitedResources = map[coreV1.ResourceName]bool{
+	coreV1.ResourceCPU:              true,
+	coreV1.ResourceMemory:           true,
+	coreV1.ResourceEphemeralStorage: true,
+}
+
+// A node is a simulated node of a node pool, with the pods of the workload scheduled on it so far.
+type node struct {
+	name        string
+	labels      labels.Set
+	taints      []coreV1.Taint
+	allocatable coreV1.ResourceList
+
+	requested coreV1.ResourceList
+	pods      int
+}
+
+// newNodes returns the nodes of the given pools.
+func newNodes(pools []config.NodePool) []*node {
+	var nodes []*node
+	for _, pool := range pools {
+		count := pool.Count
+		if count == 0 {
+			count = 1
+		}
+		allocatable := pool.Allocatable.DeepCopy()
+		if allocatable == nil {
+			allocatable = make(coreV1.ResourceList)
+		}
+		if _, found := allocatable[coreV1.ResourcePods]; !found {
+			allocatable[coreV1.ResourcePods] = *resource.NewQuantity(defaultAllocatablePods, resource.DecimalSI)
+		}
+		for i := 0; i < count; i++ {
+			name := pool.Name + "-" + strconv.Itoa(i)
+			nodeLabels := labels.Set{}
+			for key, value := range pool.Labels {
+				nodeLabels[key] = value
+			}
+			nodeLabels[coreV1.LabelHostname] = name
+			if len(pool.Zones) > 0 {
+				nodeLabels[coreV1.LabelTopologyZone] = pool.Zones[i%len(pool.Zones)]
+			}
+			nodes = append(nodes, &node{
+				name:        name,
+				labels:      nodeLabels,
+				taints:      pool.Taints,
+				allocatable: allocatable,
+				requested:   make(coreV1.ResourceList),
+			})
+		}
+	}
+	return nodes
+}
+
+// matchesNodeSelector returns whether the pod's node selector and required node affinity select the node.
+func (n *node) matchesNodeSelector(podSpec *coreV1.PodSpec) bool {
+	if !labels.SelectorFromSet(podSpec.NodeSelector).Matches(n.labels) {
+		return false
+	}
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil ||
+		podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	// Terms are ORed, the requirements of a term are ANDed.
+	for _, term := range podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		matches := true
+		for _, requirement := range term.MatchExpressions {
+			value, found := n.labels[requirement.Key]
+			matches = matches && matchesRequirement(requirement, value, found)
+		}
+		for _, requirement := range term.MatchFields {
+			// metadata.name is the only field supported by the API server.
+			matches = matches && requirement.Key == "metadata.name" && matchesRequirement(requirement, n.name, true)
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRequirement(requirement coreV1.NodeSelectorRequirement, value string, found bool) bool {
+	switch requirement.Operator {
+	case coreV1.NodeSelectorOpIn:
+		return found && contains(requirement.Values, value)
+	case coreV1.NodeSelectorOpNotIn:
+		return !found || !contains(requirement.Values, value)
+	case coreV1.NodeSelectorOpExists:
+		return found
+	case coreV1.NodeSelectorOpDoesNotExist:
+		return !found
+	case coreV1.NodeSelectorOpGt, coreV1.NodeSelectorOpLt:
+		if !found || len(requirement.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		bound, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == coreV1.NodeSelectorOpGt {
+			return actual > bound
+		}
+		return actual < bound
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// untoleratedTaint returns a taint of the node that keeps the pod from being scheduled on it, if any.
+func (n *node) untoleratedTaint(podSpec *coreV1.PodSpec) (coreV1.Taint, bool) {
+	for i := range n.taints {
+		taint := &n.taints[i]
+		if taint.Effect != coreV1.TaintEffectNoSchedule && taint.Effect != coreV1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for j := range podSpec.Tolerations {
+			if podSpec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return *taint, true
+		}
+	}
+	return coreV1.Taint{}, false
+}
+
+// insufficientResources returns the reasons why the node doesn't have room for a pod with the given requests.
+func (n *node) insufficientResources(requests coreV1.ResourceList) []string {
+	var reasons []string
+	if int64(n.pods+1) > n.allocatable.Pods().Value() {
+		reasons = append(reasons, "Too many pods")
+	}
+	for _, name := range sortedResourceNames(requests) {
+		request := requests[name]
+		if request.IsZero() {
+			continue
+		}
+		allocatable, found := n.allocatable[name]
+		if !found {
+			if unlimitedResources[name] {
+				continue
+			}
+			allocatable = resource.Quantity{}
+		}
+		total := n.requested[name].DeepCopy()
+		total.Add(request)
+		if total.Cmp(allocatable) > 0 {
+			reasons = append(reasons, fmt.Sprintf("Insufficient %s", name))
+		}
+	}
+	return reasons
+}
+
+// schedule records a pod with the given requests as scheduled on the node.
+func (n *node) schedule(requests coreV1.ResourceList) {
+	n.pods++
+	for name, request := range requests {
+		total := n.requested[name].DeepCopy()
+		total.Add(request)
+		n.requested[name] = total
+	}
+}
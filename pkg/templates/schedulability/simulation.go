@@ -0,0 +1,263 @@
+package schedulability
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// The reasons why a node rejects a pod, worded like the events of the Kubernetes scheduler.
+const (
+	nodeSelectorMismatch = "node(s) didn't match Pod's node affinity/selector"
+	antiAffinityMismatch = "node(s) didn't match pod anti-affinity rules"
+	spreadMismatch       = "node(s) didn't match pod topology spread constraints"
+	spreadMissingLabel   = "node(s) didn't match pod topology spread constraints (missing required label)"
+	untoleratedTaintFmt  = "node(s) had untolerated taint {%s: %s}"
+)
+
+// A workload describes the pods of an object, which are scheduled one after the other.
+type workload struct {
+	namespace string
+	labels    labels.Set
+	spec      *coreV1.PodSpec
+	requests  coreV1.ResourceList
+
+	antiAffinityTerms []coreV1.PodAffinityTerm
+	spreadConstraints []spreadConstraint
+}
+
+type spreadConstraint struct {
+	coreV1.TopologySpreadConstraint
+	// eligible are the nodes whose domains are taken into account to compute the skew.
+	eligible []*node
+}
+
+// newWorkload returns the workload of pods with the given labels and spec, in the given namespace.
+func newWorkload(namespace string, podLabels map[string]string, podSpec customtypes.PodSpec, nodes []*node) *workload {
+	w := &workload{
+		namespace: namespace,
+		labels:    podLabels,
+		spec:      &podSpec.PodSpec,
+		requests:  podRequests(podSpec),
+	}
+	// Only the terms and constraints that select the pods of the workload itself are simulated, since the
+	// pods of other workloads are not scheduled.
+	if affinity := w.spec.Affinity; affinity != nil && affinity.PodAntiAffinity != nil {
+		for _, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if w.selectsOwnPods(term.LabelSelector) &&
+				(len(term.Namespaces) == 0 || term.NamespaceSelector != nil || contains(term.Namespaces, namespace)) {
+				w.antiAffinityTerms = append(w.antiAffinityTerms, term)
+			}
+		}
+	}
+	for _, constraint := range w.spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != coreV1.DoNotSchedule || !w.selectsOwnPods(constraint.LabelSelector) {
+			continue
+		}
+		spread := spreadConstraint{TopologySpreadConstraint: constraint}
+		for _, n := range nodes {
+			if _, found := n.labels[constraint.TopologyKey]; !found {
+				continue
+			}
+			if (constraint.NodeAffinityPolicy == nil || *constraint.NodeAffinityPolicy == coreV1.NodeInclusionPolicyHonor) &&
+				!n.matchesNodeSelector(w.spec) {
+				continue
+			}
+			if constraint.NodeTaintsPolicy != nil && *constraint.NodeTaintsPolicy == coreV1.NodeInclusionPolicyHonor {
+				if _, untolerated := n.untoleratedTaint(w.spec); untolerated {
+					continue
+				}
+			}
+			spread.eligible = append(spread.eligible, n)
+		}
+		w.spreadConstraints = append(w.spreadConstraints, spread)
+	}
+	return w
+}
+
+func (w *workload) selectsOwnPods(labelSelector *metaV1.LabelSelector) bool {
+	if labelSelector == nil {
+		return false
+	}
+	selector, err := metaV1.LabelSelectorAsSelector(labelSelector)
+	return err == nil && selector.Matches(w.labels)
+}
+
+// podRequests returns the resources requested by a pod. Following the API server, containers that only set limits
+// request their limits. Init containers run one after the other, and native sidecars keep running alongside the
+// init containers that follow them and the regular containers.
+func podRequests(podSpec customtypes.PodSpec) coreV1.ResourceList {
+	regular := make(coreV1.ResourceList)
+	sidecars := make(coreV1.ResourceList)
+	initMax := make(coreV1.ResourceList)
+	for _, container := range podSpec.PodSpec.InitContainers {
+		requests := containerRequests(container)
+		if podSpec.IsNativeSidecar(container.Name) {
+			addResources(sidecars, requests)
+			continue
+		}
+		addResources(requests, sidecars)
+		maxResources(initMax, requests)
+	}
+	for _, container := range podSpec.PodSpec.Containers {
+		addResources(regular, containerRequests(container))
+	}
+	addResources(regular, sidecars)
+	maxResources(regular, initMax)
+	addResources(regular, podSpec.PodSpec.Overhead)
+	return regular
+}
+
+func containerRequests(container coreV1.Container) coreV1.ResourceList {
+	requests := container.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = make(coreV1.ResourceList)
+	}
+	for name, limit := range container.Resources.Limits {
+		if _, found := requests[name]; !found {
+			requests[name] = limit.DeepCopy()
+		}
+	}
+	return requests
+}
+
+func addResources(total, resources coreV1.ResourceList) {
+	for name, quantity := range resources {
+		sum := total[name].DeepCopy()
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+func maxResources(total, resources coreV1.ResourceList) {
+	for name, quantity := range resources {
+		if current, found := total[name]; !found || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func sortedResourceNames(resources coreV1.ResourceList) []coreV1.ResourceName {
+	names := make([]coreV1.ResourceName, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// A simulationResult is the outcome of scheduling the replicas of a workload.
+type simulationResult struct {
+	scheduled int
+	// reasons counts, for the first replica that couldn't be scheduled, the nodes that rejected it for each reason.
+	reasons map[string]int
+}
+
+// simulate schedules the given number of replicas of the workload on the nodes, one after the other. Like the
+// Kubernetes scheduler, each replica goes to a feasible node, preferring the nodes with the fewest replicas so far.
+func simulate(nodes []*node, w *workload, replicas int) simulationResult {
+	for scheduled := 0; scheduled < replicas; scheduled++ {
+		reasons := make(map[string]int)
+		occupiedDomains := w.occupiedDomains(nodes)
+		minCounts, domainCounts := w.spreadCounts()
+		var chosen *node
+		for _, n := range nodes {
+			nodeReasons := w.reject(n, occupiedDomains, minCounts, domainCounts)
+			for _, reason := range nodeReasons {
+				reasons[reason]++
+			}
+			if len(nodeReasons) == 0 && (chosen == nil || n.pods < chosen.pods) {
+				chosen = n
+			}
+		}
+		if chosen == nil {
+			return simulationResult{scheduled: scheduled, reasons: reasons}
+		}
+		chosen.schedule(w.requests)
+	}
+	return simulationResult{scheduled: replicas}
+}
+
+// occupiedDomains returns, for each anti-affinity term, the values of its topology key that already have a replica.
+func (w *workload) occupiedDomains(nodes []*node) []map[string]bool {
+	occupied := make([]map[string]bool, len(w.antiAffinityTerms))
+	for i, term := range w.antiAffinityTerms {
+		occupied[i] = make(map[string]bool)
+		for _, n := range nodes {
+			if value, found := n.labels[term.TopologyKey]; found && n.pods > 0 {
+				occupied[i][value] = true
+			}
+		}
+	}
+	return occupied
+}
+
+// spreadCounts returns, for each spread constraint, the minimum number of replicas in its eligible domains,
+// and the number of replicas in each domain.
+func (w *workload) spreadCounts() ([]int, []map[string]int) {
+	minCounts := make([]int, len(w.spreadConstraints))
+	domainCounts := make([]map[string]int, len(w.spreadConstraints))
+	for i, constraint := range w.spreadConstraints {
+		domainCounts[i] = make(map[string]int)
+		for _, n := range constraint.eligible {
+			domainCounts[i][n.labels[constraint.TopologyKey]] += n.pods
+		}
+		first := true
+		for _, count := range domainCounts[i] {
+			if first || count < minCounts[i] {
+				minCounts[i] = count
+				first = false
+			}
+		}
+		// If there are fewer domains than required, the missing domains count as empty ones.
+		if constraint.MinDomains != nil && len(domainCounts[i]) < int(*constraint.MinDomains) {
+			minCounts[i] = 0
+		}
+	}
+	return minCounts, domainCounts
+}
+
+// reject returns the reasons why the next replica of the workload can't be scheduled on the node, if any.
+func (w *workload) reject(n *node, occupiedDomains []map[string]bool, minCounts []int, domainCounts []map[string]int) []string {
+	if !n.matchesNodeSelector(w.spec) {
+		return []string{nodeSelectorMismatch}
+	}
+	if taint, found := n.untoleratedTaint(w.spec); found {
+		return []string{fmt.Sprintf(untoleratedTaintFmt, taint.Key, taint.Value)}
+	}
+	if reasons := n.insufficientResources(w.requests); len(reasons) > 0 {
+		return reasons
+	}
+	for i, term := range w.antiAffinityTerms {
+		if value, found := n.labels[term.TopologyKey]; found && occupiedDomains[i][value] {
+			return []string{antiAffinityMismatch}
+		}
+	}
+	for i, constraint := range w.spreadConstraints {
+		value, found := n.labels[constraint.TopologyKey]
+		if !found {
+			return []string{spreadMissingLabel}
+		}
+		if domainCounts[i][value]+1-minCounts[i] > int(constraint.MaxSkew) {
+			return []string{spreadMismatch}
+		}
+	}
+	return nil
+}
+
+// formatReasons describes why no node is available, like the events of the Kubernetes scheduler.
+func formatReasons(nodeCount int, reasons map[string]int) string {
+	descriptions := make([]string, 0, len(reasons))
+	for reason, count := range reasons {
+		descriptions = append(descriptions, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(descriptions)
+	return fmt.Sprintf("0/%d nodes are available: %s", nodeCount, strings.Join(descriptions, ", "))
+}
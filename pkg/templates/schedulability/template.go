@@ -0,0 +1,103 @@
+package schedulability
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/schedulability/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	batchV1Beta1 "k8s.io/api/batch/v1beta1"
+)
+
+const (
+	templateKey = "schedulability"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Schedulability",
+		Key:         templateKey,
+		Description: "Flag workloads whose replicas can't all be scheduled on the node pools declared in the config",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				clusterCtx, ok := lintCtx.(lintcontext.ClusterContext)
+				if !ok || len(clusterCtx.NodePools()) == 0 {
+					return nil
+				}
+				podTemplate, found := extract.PodTemplateSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				replicas := replicasOf(object.K8sObject)
+				if replicas == 0 {
+					return nil
+				}
+
+				// Each workload is simulated on empty nodes, since whether the cluster has room for all of them
+				// depends on what else runs in it.
+				nodes := newNodes(clusterCtx.NodePools())
+				w := newWorkload(object.K8sObject.GetNamespace(), podTemplate.Labels, podSpec, nodes)
+				result := simulate(nodes, w, replicas)
+				if result.scheduled == replicas {
+					return nil
+				}
+				reasons := formatReasons(len(nodes), result.reasons)
+				if result.scheduled == 0 {
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("pods can't be scheduled on the declared node pools: %s", reasons),
+					}}
+				}
+				return []diagnostic.Diagnostic{{
+					Message: fmt.Sprintf("only %d of %d replicas can be scheduled on the declared node pools: %s", result.scheduled, replicas, reasons),
+				}}
+			}, nil
+		}),
+	})
+}
+
+// replicasOf returns the number of pods of the object that run at the same time. DaemonSets are checked with a
+// single pod, which has to fit on at least one node.
+func replicasOf(obj k8sutil.Object) int {
+	switch obj := obj.(type) {
+	case *appsV1.DaemonSet:
+		return 1
+	case *batchV1.Job:
+		return jobParallelism(obj.Spec)
+	case *batchV1.CronJob:
+		return jobParallelism(obj.Spec.JobTemplate.Spec)
+	case *batchV1Beta1.CronJob:
+		return jobParallelism(obj.Spec.JobTemplate.Spec)
+	}
+	if replicas, found := extract.Replicas(obj); found {
+		return int(replicas)
+	}
+	return 1
+}
+
+func jobParallelism(spec batchV1.JobSpec) int {
+	parallelism := 1
+	if spec.Parallelism != nil {
+		parallelism = int(*spec.Parallelism)
+	}
+	if spec.Completions != nil && int(*spec.Completions) < parallelism {
+		parallelism = int(*spec.Completions)
+	}
+	return parallelism
+}
@@ -0,0 +1,159 @@
+package schedulability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/schedulability/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	gpuResource coreV1.ResourceName = "nvidia.com/gpu"
+)
+
+func TestSchedulability(t *testing.T) {
+	suite.Run(t, new(SchedulabilityTestSuite))
+}
+
+type SchedulabilityTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *SchedulabilityTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *SchedulabilityTestSuite) addDeployment(name string, replicas int32, cpu string, modify func(podSpec *coreV1.PodSpec)) {
+	podLabels := map[string]string{"app": name}
+	deployment := &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Spec: appsV1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metaV1.LabelSelector{MatchLabels: podLabels},
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: podLabels},
+				Spec: coreV1.PodSpec{
+					Containers: []coreV1.Container{{
+						Name: "app",
+						Resources: coreV1.ResourceRequirements{
+							Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse(cpu)},
+						},
+					}},
+				},
+			},
+		},
+	}
+	if modify != nil {
+		modify(&deployment.Spec.Template.Spec)
+	}
+	s.ctx.AddObject(name, deployment)
+}
+
+func (s *SchedulabilityTestSuite) setNodePools() {
+	s.ctx.SetNodePools(
+		config.NodePool{
+			Name:  "general",
+			Count: 3,
+			Allocatable: coreV1.ResourceList{
+				coreV1.ResourceCPU:    resource.MustParse("4"),
+				coreV1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Zones: []string{"zone-a", "zone-b", "zone-c"},
+		},
+		config.NodePool{
+			Name: "gpu",
+			Allocatable: coreV1.ResourceList{
+				coreV1.ResourceCPU: resource.MustParse("8"),
+				gpuResource:        resource.MustParse("1"),
+			},
+			Labels: map[string]string{"accelerator": "gpu"},
+			Taints: []coreV1.Taint{{Key: "nvidia.com/gpu", Value: "present", Effect: coreV1.TaintEffectNoSchedule}},
+		},
+	)
+}
+
+func (s *SchedulabilityTestSuite) TestNoNodePools() {
+	s.addDeployment("too-big", 3, "64", nil)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param:       params.Params{},
+			Diagnostics: nil,
+		},
+	})
+}
+
+func (s *SchedulabilityTestSuite) TestScheduling() {
+	const (
+		fits         = "fits"
+		tooBig       = "too-big"
+		gpu          = "gpu"
+		antiAffinity = "anti-affinity"
+		zoneSpread   = "zone-spread"
+	)
+	s.setNodePools()
+	s.addDeployment(fits, 6, "1500m", nil)
+	s.addDeployment(tooBig, 1, "6", nil)
+	s.addDeployment(gpu, 2, "1", func(podSpec *coreV1.PodSpec) {
+		podSpec.NodeSelector = map[string]string{"accelerator": "gpu"}
+		podSpec.Tolerations = []coreV1.Toleration{{Key: "nvidia.com/gpu", Operator: coreV1.TolerationOpExists}}
+		podSpec.Containers[0].Resources.Limits = coreV1.ResourceList{gpuResource: resource.MustParse("1")}
+	})
+	s.addDeployment(antiAffinity, 5, "1", func(podSpec *coreV1.PodSpec) {
+		podSpec.Affinity = &coreV1.Affinity{
+			PodAntiAffinity: &coreV1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []coreV1.PodAffinityTerm{{
+					LabelSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": antiAffinity}},
+					TopologyKey:   coreV1.LabelHostname,
+				}},
+			},
+		}
+	})
+	s.addDeployment(zoneSpread, 4, "1", func(podSpec *coreV1.PodSpec) {
+		minDomains := int32(4)
+		podSpec.TopologySpreadConstraints = []coreV1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			MinDomains:        &minDomains,
+			TopologyKey:       coreV1.LabelTopologyZone,
+			WhenUnsatisfiable: coreV1.DoNotSchedule,
+			LabelSelector:     &metaV1.LabelSelector{MatchLabels: map[string]string{"app": zoneSpread}},
+		}}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				fits: {},
+				tooBig: {
+					{Message: "pods can't be scheduled on the declared node pools: 0/4 nodes are available: " +
+						"1 node(s) had untolerated taint {nvidia.com/gpu: present}, 3 Insufficient cpu"},
+				},
+				gpu: {
+					{Message: "only 1 of 2 replicas can be scheduled on the declared node pools: 0/4 nodes are available: " +
+						"1 Insufficient nvidia.com/gpu, 3 node(s) didn't match Pod's node affinity/selector"},
+				},
+				antiAffinity: {
+					{Message: "only 3 of 5 replicas can be scheduled on the declared node pools: 0/4 nodes are available: " +
+						"1 node(s) had untolerated taint {nvidia.com/gpu: present}, 3 node(s) didn't match pod anti-affinity rules"},
+				},
+				zoneSpread: {
+					{Message: "only 3 of 4 replicas can be scheduled on the declared node pools: 0/4 nodes are available: " +
+						"1 node(s) had untolerated taint {nvidia.com/gpu: present}, 3 node(s) didn't match pod topology spread constraints"},
+				},
+			},
+		},
+	})
+}
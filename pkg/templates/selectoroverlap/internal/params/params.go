@@ -0,0 +1,10 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// list of matchers for the names of services that are allowed to select the pods of several workloads,
+	// e.g. to send traffic to both the stable and the canary deployment of an application.
+	// +matcher
+	AllowedServices []string
+}
@@ -0,0 +1,129 @@
+package selectoroverlap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/selectoroverlap/internal/params"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	templateKey = "selector-overlap"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Overlapping Selectors",
+		Key:         templateKey,
+		Description: "Flag workloads whose selector matches the pods of another workload in the same namespace, and services whose selector matches the pods of several workloads",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike, objectkinds.Service},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedServiceMatchers := make([]func(string) bool, 0, len(p.AllowedServices))
+			for _, allowed := range p.AllowedServices {
+				m, err := matcher.ForString(allowed)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid allowed service %s", allowed)
+				}
+				allowedServiceMatchers = append(allowedServiceMatchers, m)
+			}
+			isAllowedService := func(name string) bool {
+				for _, m := range allowedServiceMatchers {
+					if m(name) {
+						return true
+					}
+				}
+				return false
+			}
+
+			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				if service, ok := object.K8sObject.(*v1.Service); ok {
+					if isAllowedService(service.Name) {
+						return nil
+					}
+					return checkService(lintCtx, service)
+				}
+				return checkWorkload(lintCtx, object)
+			}, nil
+		}),
+	})
+}
+
+// checkWorkload flags other workloads in the same namespace whose pod template matches the selector of the
+// given workload, since the controllers of both workloads would then fight over the same pods.
+func checkWorkload(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+	selector, found := extract.Selector(object.K8sObject)
+	// An empty selector is flagged by the mismatching-selector check, and Jobs don't need one.
+	if !found || selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return nil
+	}
+	labelSelector, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil
+	}
+	var results []diagnostic.Diagnostic
+	for _, other := range lintCtx.Objects() {
+		if other.K8sObject == object.K8sObject || other.K8sObject.GetNamespace() != object.K8sObject.GetNamespace() {
+			continue
+		}
+		podTemplateSpec, hasPods := extract.PodTemplateSpec(other.K8sObject)
+		if !hasPods || !labelSelector.Matches(labels.Set(podTemplateSpec.Labels)) {
+			continue
+		}
+		results = append(results, diagnostic.Diagnostic{
+			Message:        fmt.Sprintf("selector (%s) also matches the pods of %s", labelSelector, describe(other)),
+			RelatedObjects: []lintcontext.Object{other},
+		})
+	}
+	return results
+}
+
+// checkService flags services whose selector matches the pod templates of more than one workload.
+func checkService(lintCtx lintcontext.LintContext, service *v1.Service) []diagnostic.Diagnostic {
+	if service.Spec.Type == v1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+		return nil
+	}
+	labelSelector := labels.SelectorFromSet(service.Spec.Selector)
+	var matching []lintcontext.Object
+	for _, obj := range lintCtx.Objects() {
+		if obj.K8sObject.GetNamespace() != service.Namespace {
+			continue
+		}
+		podTemplateSpec, hasPods := extract.PodTemplateSpec(obj.K8sObject)
+		if hasPods && labelSelector.Matches(labels.Set(podTemplateSpec.Labels)) {
+			matching = append(matching, obj)
+		}
+	}
+	if len(matching) < 2 {
+		return nil
+	}
+	descriptions := make([]string, 0, len(matching))
+	for _, obj := range matching {
+		descriptions = append(descriptions, describe(obj))
+	}
+	sort.Strings(descriptions)
+	return []diagnostic.Diagnostic{{
+		Message:        fmt.Sprintf("service selector (%s) matches the pods of %d workloads: %s", labelSelector, len(matching), strings.Join(descriptions, ", ")),
+		RelatedObjects: matching,
+	}}
+}
+
+func describe(obj lintcontext.Object) string {
+	return fmt.Sprintf("%s %q", obj.K8sObject.GetObjectKind().GroupVersionKind().Kind, obj.K8sObject.GetName())
+}
@@ -0,0 +1,134 @@
+package selectoroverlap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/selectoroverlap/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	web       = "web"
+	webCanary = "web-canary"
+	worker    = "worker"
+	webSvc    = "web-svc"
+)
+
+func TestSelectorOverlap(t *testing.T) {
+	suite.Run(t, new(SelectorOverlapTestSuite))
+}
+
+type SelectorOverlapTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *SelectorOverlapTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *SelectorOverlapTestSuite) addDeployment(name, namespace string, selector *metaV1.LabelSelector, podLabels map[string]string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Kind = "Deployment"
+		deployment.Namespace = namespace
+		deployment.Spec.Selector = selector
+		deployment.Spec.Template.Labels = podLabels
+	})
+}
+
+func (s *SelectorOverlapTestSuite) addService(name, namespace string, selector map[string]string) {
+	s.ctx.AddMockService(s.T(), name)
+	s.ctx.ModifyService(s.T(), name, func(service *v1.Service) {
+		service.Kind = "Service"
+		service.Namespace = namespace
+		service.Spec.Selector = selector
+	})
+}
+
+func (s *SelectorOverlapTestSuite) TestDistinctSelectors() {
+	s.addDeployment(web, "", &metaV1.LabelSelector{MatchLabels: map[string]string{"app": web}}, map[string]string{"app": web})
+	s.addDeployment(worker, "", &metaV1.LabelSelector{MatchLabels: map[string]string{"app": worker}}, map[string]string{"app": worker})
+	s.addService(webSvc, "", map[string]string{"app": web})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				web:    {},
+				worker: {},
+				webSvc: {},
+			},
+		},
+	})
+}
+
+func (s *SelectorOverlapTestSuite) TestOverlappingSelectors() {
+	s.addDeployment(web, "", &metaV1.LabelSelector{MatchLabels: map[string]string{"app": web}}, map[string]string{"app": web})
+	s.addDeployment(webCanary, "", &metaV1.LabelSelector{
+		MatchLabels: map[string]string{"app": web},
+		MatchExpressions: []metaV1.LabelSelectorRequirement{
+			{Key: "track", Operator: metaV1.LabelSelectorOpIn, Values: []string{"canary"}},
+		},
+	}, map[string]string{"app": web, "track": "canary"})
+	s.addService(webSvc, "", map[string]string{"app": web})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				web: {
+					{Message: `selector (app=web) also matches the pods of Deployment "web-canary"`},
+				},
+				webCanary: {},
+				webSvc: {
+					{Message: `service selector (app=web) matches the pods of 2 workloads: Deployment "web", Deployment "web-canary"`},
+				},
+			},
+		},
+		{
+			Param: params.Params{AllowedServices: []string{"^web-"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				web: {
+					{Message: `selector (app=web) also matches the pods of Deployment "web-canary"`},
+				},
+				webCanary: {},
+				webSvc:    {},
+			},
+		},
+	})
+}
+
+func (s *SelectorOverlapTestSuite) TestDifferentNamespaces() {
+	s.addDeployment(web, "team-a", &metaV1.LabelSelector{MatchLabels: map[string]string{"app": web}}, map[string]string{"app": web})
+	s.addDeployment(webCanary, "team-b", &metaV1.LabelSelector{MatchLabels: map[string]string{"app": web}}, map[string]string{"app": web})
+	s.addService(webSvc, "team-a", map[string]string{"app": web})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				web:       {},
+				webCanary: {},
+				webSvc:    {},
+			},
+		},
+	})
+}
+
+func (s *SelectorOverlapTestSuite) TestInvalidAllowedService() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param:                    params.Params{AllowedServices: []string{"semver:not a constraint"}},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -0,0 +1,102 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+	_ = matcher.Validate
+
+	fieldsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "fields",
+	"Type": "array",
+	"Description": "The fields to look for. If empty, all of them are looked for.",
+	"Examples": null,
+	"Enum": [
+		"status",
+		"uid",
+		"resourceVersion",
+		"creationTimestamp",
+		"managedFields",
+		"ownerReferences",
+		"generation",
+		"selfLink",
+		"last-applied-configuration"
+	],
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "Fields",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		fieldsParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	for _, value := range p.Fields {
+		var found bool
+		for _, allowedValue := range []string{
+			"status",
+			"uid",
+			"resourceVersion",
+			"creationTimestamp",
+			"managedFields",
+			"ownerReferences",
+			"generation",
+			"selfLink",
+			"last-applied-configuration",
+		}{
+			if value == allowedValue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			validationErrors = append(validationErrors, fmt.Sprintf("param fields has invalid value %q, must be one of [status uid resourceVersion creationTimestamp managedFields ownerReferences generation selfLink last-applied-configuration]", p.Fields))
+		}
+	}
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
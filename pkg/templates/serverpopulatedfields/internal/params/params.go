@@ -0,0 +1,17 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The fields to look for. If empty, all of them are looked for.
+	// +enum=status
+	// +enum=uid
+	// +enum=resourceVersion
+	// +enum=creationTimestamp
+	// +enum=managedFields
+	// +enum=ownerReferences
+	// +enum=generation
+	// +enum=selfLink
+	// +enum=last-applied-configuration
+	Fields []string
+}
@@ -0,0 +1,177 @@
+package serverpopulatedfields
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"gopkg.in/yaml.v3"
+)
+
+// source is the YAML source of an object, which the fields are located in to remove them.
+type source struct {
+	lines []string
+	// object is the mapping of the object, which is an item of the source if it is a list.
+	object *yaml.Node
+	// nodeLines are the sorted lines at which nodes start.
+	nodeLines []int
+}
+
+// parseSource returns the source of the object, or nil if it is unknown or cannot be parsed.
+func parseSource(object lintcontext.Object) *source {
+	if len(object.Metadata.Raw) == 0 {
+		return nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(object.Metadata.Raw, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	s := &source{
+		lines:  strings.Split(string(object.Metadata.Raw), "\n"),
+		object: findObject(doc.Content[0], object.GetK8sObjectName()),
+	}
+	if s.object == nil {
+		return nil
+	}
+	s.collectLines(&doc)
+	sort.Ints(s.nodeLines)
+	return s
+}
+
+// findObject returns the mapping of the given object, which is either the root of the source or, for lists,
+// the item with the same kind and name.
+func findObject(root *yaml.Node, info lintcontext.K8sObjectInfo) *yaml.Node {
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	_, kind := mappingEntry(root, "kind")
+	_, items := mappingEntry(root, "items")
+	if kind == nil || !strings.HasSuffix(kind.Value, "List") || items == nil || items.Kind != yaml.SequenceNode {
+		return root
+	}
+	for _, item := range items.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		_, itemKind := mappingEntry(item, "kind")
+		_, metadata := mappingEntry(item, "metadata")
+		if itemKind == nil || itemKind.Value != info.GroupVersionKind.Kind || metadata == nil {
+			continue
+		}
+		_, name := mappingEntry(metadata, "name")
+		_, namespace := mappingEntry(metadata, "namespace")
+		if name != nil && name.Value == info.Name && (namespace == nil || namespace.Value == info.Namespace) {
+			return item
+		}
+	}
+	return nil
+}
+
+// mappingEntry returns the key and value nodes of the given key of the mapping, or nils if it is not set.
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func (s *source) collectLines(n *yaml.Node) {
+	s.nodeLines = append(s.nodeLines, n.Line)
+	for _, child := range n.Content {
+		s.collectLines(child)
+	}
+}
+
+// removalFix returns the first line of the given fields in the source, and a fix removing them.
+// The fix is nil if a field cannot be removed line-wise, e.g. because it is written in flow style.
+func (s *source) removalFix(found []field, paths []string) (int, *diagnostic.Fix) {
+	var replacements []diagnostic.Replacement
+	firstLine := 0
+	for _, f := range found {
+		start, end := s.locate(f.path)
+		if start == 0 {
+			return firstLine, nil
+		}
+		if firstLine == 0 || start < firstLine {
+			firstLine = start
+		}
+		replacements = append(replacements, s.deletion(start, end))
+	}
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].StartLine < replacements[j].StartLine
+	})
+	return firstLine, &diagnostic.Fix{
+		Description:  fmt.Sprintf("Remove %s from the manifest.", strings.Join(paths, ", ")),
+		Replacements: replacements,
+	}
+}
+
+// locate returns the range of lines that hold the field at the given path, or zeros if the field is not written
+// as a block mapping entry on lines of its own. If the field is the only entry of a mapping other than the
+// object, e.g. the only annotation, the range holds the whole mapping instead, so that no empty mapping is left.
+func (s *source) locate(path []string) (int, int) {
+	mappings := []*yaml.Node{s.object}
+	var key, value *yaml.Node
+	for _, elem := range path {
+		key, value = mappingEntry(mappings[len(mappings)-1], elem)
+		if key == nil {
+			return 0, 0
+		}
+		mappings = append(mappings, value)
+	}
+	for i := len(path) - 1; i > 0 && len(mappings[i].Content) == 2; i-- {
+		key, value = mappingEntry(mappings[i-1], path[i-1])
+	}
+	for _, mapping := range mappings[:len(mappings)-1] {
+		if mapping.Style&yaml.FlowStyle != 0 {
+			return 0, 0
+		}
+	}
+	if key.Line > len(s.lines) || key.Column-1 > len(s.lines[key.Line-1]) ||
+		strings.TrimSpace(s.lines[key.Line-1][:key.Column-1]) != "" {
+		return 0, 0
+	}
+	// The value spans the lines up to the start of the next node, except for trailing blank and comment lines.
+	last := lastLine(value)
+	end := len(s.lines)
+	if next := sort.SearchInts(s.nodeLines, last+1); next < len(s.nodeLines) {
+		end = s.nodeLines[next] - 1
+	}
+	for end > last {
+		if trimmed := strings.TrimSpace(s.lines[end-1]); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		end--
+	}
+	return key.Line, end
+}
+
+// lastLine returns the line of the last node in the given node.
+func lastLine(n *yaml.Node) int {
+	last := n.Line
+	for _, child := range n.Content {
+		if line := lastLine(child); line > last {
+			last = line
+		}
+	}
+	return last
+}
+
+// deletion returns a replacement deleting the given range of lines.
+func (s *source) deletion(start, end int) diagnostic.Replacement {
+	if end < len(s.lines) || start == 1 {
+		return diagnostic.Replacement{StartLine: start, StartColumn: 1, EndLine: end + 1, EndColumn: 1}
+	}
+	// The last line has no line break to delete, so the line break before the range is deleted instead.
+	return diagnostic.Replacement{
+		StartLine: start - 1, StartColumn: len(s.lines[start-2]) + 1,
+		EndLine: end, EndColumn: len(s.lines[end-1]) + 1,
+	}
+}
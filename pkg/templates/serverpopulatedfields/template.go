@@ -0,0 +1,130 @@
+package serverpopulatedfields
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/serverpopulatedfields/internal/params"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	templateKey = "server-populated-fields"
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// A field is a field that the API server or kubectl populates, and that should not be part of a manifest.
+type field struct {
+	// name is the value of the template parameter selecting the field.
+	name string
+	path []string
+}
+
+// String returns the path of the field, e.g. metadata.uid.
+func (f field) String() string {
+	if len(f.path) == 3 && f.path[1] == "annotations" {
+		return fmt.Sprintf("metadata.annotations[%q]", f.path[2])
+	}
+	return strings.Join(f.path, ".")
+}
+
+// fields are the fields the template looks for, in the order they are reported.
+var fields = []field{
+	{name: "uid", path: []string{"metadata", "uid"}},
+	{name: "resourceVersion", path: []string{"metadata", "resourceVersion"}},
+	{name: "generation", path: []string{"metadata", "generation"}},
+	{name: "creationTimestamp", path: []string{"metadata", "creationTimestamp"}},
+	{name: "selfLink", path: []string{"metadata", "selfLink"}},
+	{name: "ownerReferences", path: []string{"metadata", "ownerReferences"}},
+	{name: "managedFields", path: []string{"metadata", "managedFields"}},
+	{name: "last-applied-configuration", path: []string{"metadata", "annotations", lastAppliedConfigAnnotation}},
+	{name: "status", path: []string{"status"}},
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Server-Populated Fields",
+		Key:         templateKey,
+		Description: "Flag fields populated by the API server or kubectl, such as status, metadata.uid, metadata.resourceVersion and metadata.managedFields, that end up in manifests copied from kubectl get -o yaml",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			enabled := set.NewStringSet(p.Fields...)
+			var lookedFor []field
+			for _, f := range fields {
+				if enabled.IsEmpty() || enabled.Contains(f.name) {
+					lookedFor = append(lookedFor, f)
+				}
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object.Source())
+				if err != nil {
+					return nil
+				}
+				var found []field
+				for _, f := range lookedFor {
+					value, exists, _ := unstructured.NestedFieldNoCopy(content, f.path...)
+					if exists && !isEmpty(value) {
+						found = append(found, f)
+					}
+				}
+				if len(found) == 0 {
+					return nil
+				}
+				return []diagnostic.Diagnostic{report(object, found)}
+			}, nil
+		}),
+	})
+}
+
+func report(object lintcontext.Object, found []field) diagnostic.Diagnostic {
+	paths := make([]string, 0, len(found))
+	for _, f := range found {
+		paths = append(paths, f.String())
+	}
+	diag := diagnostic.Diagnostic{
+		Message: fmt.Sprintf("object has fields populated by the API server or kubectl, which cause conflicts when it is applied: %s",
+			strings.Join(paths, ", ")),
+	}
+	if src := parseSource(object); src != nil {
+		diag.Line, diag.Fix = src.removalFix(found, paths)
+	}
+	return diag
+}
+
+// isEmpty returns whether the value is unset in effect, e.g. the creationTimestamp: null and status: {} that
+// kubectl create --dry-run writes, or the status of typed objects that have none, which is made of empty fields.
+func isEmpty(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		for _, elem := range value {
+			if !isEmpty(elem) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		return len(value) == 0
+	case string:
+		return value == ""
+	case int64:
+		return value == 0
+	case float64:
+		return value == 0
+	}
+	return false
+}
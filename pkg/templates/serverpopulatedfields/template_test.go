@@ -0,0 +1,176 @@
+package serverpopulatedfields
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/serverpopulatedfields/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	deploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"apps/v1","kind":"Deployment"}
+  creationTimestamp: "2021-01-01T00:00:00Z"
+  generation: 3
+  name: app
+  resourceVersion: "12345"
+  uid: 0b4f6e8c-8d5b-4a4c-9a8e-3f1f0e8e2b1a
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:1.0
+
+status:
+  replicas: 1
+  conditions:
+  - type: Available
+    status: "True"
+`
+
+	crontabYAML = `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: crontab
+  managedFields:
+  - manager: kubectl
+    operation: Update
+spec:
+  cronSpec: "* * * * */5"
+status: {lastRun: "2021-01-01T00:00:00Z"}
+`
+
+	// kubectl create --dry-run writes these empty fields, which are not reported.
+	dryRunYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  creationTimestamp: null
+  name: dry-run
+status: {}
+`
+)
+
+func TestServerPopulatedFields(t *testing.T) {
+	suite.Run(t, new(ServerPopulatedFieldsTestSuite))
+}
+
+type ServerPopulatedFieldsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ServerPopulatedFieldsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *ServerPopulatedFieldsTestSuite) addObjects() {
+	var deployment appsV1.Deployment
+	s.Require().NoError(yaml.Unmarshal([]byte(deploymentYAML), &deployment))
+	s.ctx.AddObject("app", &deployment)
+	// The source of objects is trimmed when it is loaded, so the status ends on the last line without a line break.
+	s.ctx.SetRaw("app", []byte(strings.TrimSpace(deploymentYAML)))
+
+	var crontab unstructured.Unstructured
+	s.Require().NoError(yaml.Unmarshal([]byte(crontabYAML), &crontab.Object))
+	s.ctx.AddObject("crontab", &crontab)
+	s.ctx.SetRaw("crontab", []byte(crontabYAML))
+
+	var configMap coreV1.ConfigMap
+	s.Require().NoError(yaml.Unmarshal([]byte(dryRunYAML), &configMap))
+	s.ctx.AddObject("dry-run", &configMap)
+	s.ctx.SetRaw("dry-run", []byte(dryRunYAML))
+
+	// Without the source, the fields are reported without a fix.
+	s.ctx.AddObject("generated", &coreV1.Service{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "generated", UID: "1234", OwnerReferences: []metaV1.OwnerReference{{Name: "owner"}}},
+	})
+}
+
+func (s *ServerPopulatedFieldsTestSuite) TestFields() {
+	s.addObjects()
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: `object has fields populated by the API server or kubectl, which cause conflicts when it is applied: metadata.uid, metadata.resourceVersion, metadata.generation, metadata.creationTimestamp, metadata.annotations["kubectl.kubernetes.io/last-applied-configuration"], status`},
+				},
+				"crontab": {
+					{Message: "object has fields populated by the API server or kubectl, which cause conflicts when it is applied: metadata.managedFields, status"},
+				},
+				"generated": {
+					{Message: "object has fields populated by the API server or kubectl, which cause conflicts when it is applied: metadata.uid, metadata.ownerReferences"},
+				},
+			},
+		},
+		{
+			Param: params.Params{Fields: []string{"status", "managedFields"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: "object has fields populated by the API server or kubectl, which cause conflicts when it is applied: status"},
+				},
+				"crontab": {
+					{Message: "object has fields populated by the API server or kubectl, which cause conflicts when it is applied: metadata.managedFields, status"},
+				},
+			},
+		},
+	})
+}
+
+func (s *ServerPopulatedFieldsTestSuite) TestFixes() {
+	s.addObjects()
+	checkFunc, err := s.Template.Instantiate(params.Params{})
+	s.Require().NoError(err)
+
+	diagnostics := make(map[string]diagnostic.Diagnostic)
+	for _, obj := range s.ctx.Objects() {
+		for _, diag := range checkFunc(s.ctx, obj) {
+			diagnostics[obj.GetK8sObjectName().Name] = diag
+		}
+	}
+
+	app := diagnostics["app"]
+	s.Equal(4, app.Line)
+	s.Require().NotNil(app.Fix)
+	s.Equal([]diagnostic.Replacement{
+		// The annotations are removed altogether, since the annotation is the only one.
+		{StartLine: 4, StartColumn: 1, EndLine: 7, EndColumn: 1},
+		{StartLine: 7, StartColumn: 1, EndLine: 8, EndColumn: 1},
+		{StartLine: 8, StartColumn: 1, EndLine: 9, EndColumn: 1},
+		{StartLine: 10, StartColumn: 1, EndLine: 11, EndColumn: 1},
+		{StartLine: 11, StartColumn: 1, EndLine: 12, EndColumn: 1},
+		// The last line has no line break, so the one before the status is removed instead.
+		{StartLine: 18, StartColumn: 1, EndLine: 23, EndColumn: 19},
+	}, app.Fix.Replacements)
+
+	// The status is written in flow style on a line of its own, which is removed along with it.
+	crontab := diagnostics["crontab"]
+	s.Equal(5, crontab.Line)
+	s.Require().NotNil(crontab.Fix)
+	s.Equal([]diagnostic.Replacement{
+		{StartLine: 5, StartColumn: 1, EndLine: 8, EndColumn: 1},
+		{StartLine: 10, StartColumn: 1, EndLine: 11, EndColumn: 1},
+	}, crontab.Fix.Replacements)
+
+	generated := diagnostics["generated"]
+	s.Zero(generated.Line)
+	s.Nil(generated.Fix)
+}
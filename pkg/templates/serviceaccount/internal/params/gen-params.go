@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,18 +17,18 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	serviceAccountParamDesc = util.MustParseParameterDesc(`{
 	"Name": "serviceAccount",
 	"Type": "string",
-	"Description": "A regex specifying the required service account to match.",
+	"Description": "A matcher specifying the required service account to match.",
 	"Examples": null,
 	"Enum": null,
 	"SubParameters": null,
 	"ArrayElemType": "",
 	"Required": true,
-	"NoRegex": false,
-	"NotNegatable": false,
+	"Matcher": "full",
 	"XXXStructFieldName": "ServiceAccount",
 	"XXXIsPointer": false
 }
@@ -43,6 +44,9 @@ func (p *Params) Validate() error {
 	if p.ServiceAccount == "" {
 		validationErrors = append(validationErrors, "required param serviceAccount not found")
 	}
+	if err := matcher.Validate(p.ServiceAccount, true); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("param serviceAccount has invalid value %q: %v", p.ServiceAccount, err))
+	}
 	if len(validationErrors) > 0 {
 		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
     }
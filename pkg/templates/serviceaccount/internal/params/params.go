@@ -3,7 +3,8 @@ package params
 // Params represents the params accepted by this template.
 type Params struct {
 
-	// A regex specifying the required service account to match.
+	// A matcher specifying the required service account to match.
 	// +required
+	// +matcher
 	ServiceAccount string
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
 	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
@@ -16,6 +17,7 @@ var (
 	// Use some imports in case they don't get used otherwise.
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
+	_ = matcher.Validate
 
 	forbiddenServiceTypesParamDesc = util.MustParseParameterDesc(`{
 	"Name": "forbiddenServiceTypes",
@@ -26,8 +28,7 @@ var (
 	"SubParameters": null,
 	"ArrayElemType": "string",
 	"Required": false,
-	"NoRegex": true,
-	"NotNegatable": true,
+	"Matcher": "",
 	"XXXStructFieldName": "ForbiddenServiceTypes",
 	"XXXIsPointer": false
 }
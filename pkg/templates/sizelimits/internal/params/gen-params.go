@@ -0,0 +1,150 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+	_ = matcher.Validate
+
+	maxObjectSizeKiBParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxObjectSizeKiB",
+	"Type": "integer",
+	"Description": "The maximum size of an object serialized as JSON, specified as a number of KiB. If not specified, it defaults to 1536, the default request size limit of etcd.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "MaxObjectSizeKiB",
+	"XXXIsPointer": true
+}
+`)
+
+	maxDataSizeKiBParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxDataSizeKiB",
+	"Type": "integer",
+	"Description": "The maximum total size of the data of a ConfigMap or Secret, specified as a number of KiB. If not specified, it defaults to 1024, the limit enforced by the API server.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "MaxDataSizeKiB",
+	"XXXIsPointer": true
+}
+`)
+
+	maxAnnotationsSizeKiBParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxAnnotationsSizeKiB",
+	"Type": "integer",
+	"Description": "The maximum total size of the keys and values of the annotations of an object, specified as a number of KiB. If not specified, it defaults to 256, the limit enforced by the API server.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "MaxAnnotationsSizeKiB",
+	"XXXIsPointer": true
+}
+`)
+
+	maxLabelValueLengthParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxLabelValueLength",
+	"Type": "integer",
+	"Description": "The maximum length of label values. If not specified, it defaults to 63, the limit enforced by the API server.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "MaxLabelValueLength",
+	"XXXIsPointer": true
+}
+`)
+
+	warningMarginPercentParamDesc = util.MustParseParameterDesc(`{
+	"Name": "warningMarginPercent",
+	"Type": "integer",
+	"Description": "Also report sizes that are below the limits by less than the given percentage of the limits.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "WarningMarginPercent",
+	"XXXIsPointer": false
+}
+`)
+
+	clientSideApplyParamDesc = util.MustParseParameterDesc(`{
+	"Name": "clientSideApply",
+	"Type": "boolean",
+	"Description": "Whether objects are applied with client-side kubectl apply, which copies the whole object into the kubectl.kubernetes.io/last-applied-configuration annotation, roughly doubling its size.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"Matcher": "",
+	"XXXStructFieldName": "ClientSideApply",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		maxObjectSizeKiBParamDesc,
+		maxDataSizeKiBParamDesc,
+		maxAnnotationsSizeKiBParamDesc,
+		maxLabelValueLengthParamDesc,
+		warningMarginPercentParamDesc,
+		clientSideApplyParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
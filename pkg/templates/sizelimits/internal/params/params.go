@@ -0,0 +1,28 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The maximum size of an object serialized as JSON, specified as a number of KiB.
+	// If not specified, it defaults to 1536, the default request size limit of etcd.
+	MaxObjectSizeKiB *int `json:"maxObjectSizeKiB"`
+
+	// The maximum total size of the data of a ConfigMap or Secret, specified as a number of KiB.
+	// If not specified, it defaults to 1024, the limit enforced by the API server.
+	MaxDataSizeKiB *int `json:"maxDataSizeKiB"`
+
+	// The maximum total size of the keys and values of the annotations of an object, specified as a number of KiB.
+	// If not specified, it defaults to 256, the limit enforced by the API server.
+	MaxAnnotationsSizeKiB *int `json:"maxAnnotationsSizeKiB"`
+
+	// The maximum length of label values.
+	// If not specified, it defaults to 63, the limit enforced by the API server.
+	MaxLabelValueLength *int `json:"maxLabelValueLength"`
+
+	// Also report sizes that are below the limits by less than the given percentage of the limits.
+	WarningMarginPercent int `json:"warningMarginPercent"`
+
+	// Whether objects are applied with client-side kubectl apply, which copies the whole object into the
+	// kubectl.kubernetes.io/last-applied-configuration annotation, roughly doubling its size.
+	ClientSideApply bool `json:"clientSideApply"`
+}
@@ -0,0 +1,188 @@
+package sizelimits
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/sizelimits/internal/params"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "size-limits"
+
+	bytesInKiB = 1024
+	bytesInMiB = 1024 * bytesInKiB
+
+	defaultMaxObjectSizeKiB      = 1536
+	defaultMaxDataSizeKiB        = 1024
+	defaultMaxAnnotationsSizeKiB = 256
+	defaultMaxLabelValueLength   = 63
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// limits are the limits an object is checked against, in bytes, and the sizes above which sizes are reported.
+type limits struct {
+	object, data, annotations int
+	labelValueLength          int
+	warningMarginPercent      int
+}
+
+// exceeds returns whether the size is above the limit, or close enough to it to report.
+func (l *limits) exceeds(size, limit int) bool {
+	return size > limit-limit*l.warningMarginPercent/100
+}
+
+func describe(what string, size, limit int) string {
+	if size > limit {
+		return fmt.Sprintf("%s %s, above the limit of %s", what, formatBytes(size), formatBytes(limit))
+	}
+	return fmt.Sprintf("%s %s, close to the limit of %s", what, formatBytes(size), formatBytes(limit))
+}
+
+func formatBytes(size int) string {
+	switch {
+	case size >= bytesInMiB:
+		return fmt.Sprintf("%.1fMiB", float64(size)/bytesInMiB)
+	case size >= bytesInKiB:
+		return fmt.Sprintf("%.1fKiB", float64(size)/bytesInKiB)
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+func valueOrDefault(value *int, defaultValue int) int {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Size Limits",
+		Key:         templateKey,
+		Description: "Flag objects, ConfigMap and Secret data, annotations and label values that exceed the size limits of etcd and the API server, or come close to them",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			if p.WarningMarginPercent < 0 || p.WarningMarginPercent >= 100 {
+				return nil, errors.Errorf("invalid warningMarginPercent %d, expected a percentage between 0 and 99", p.WarningMarginPercent)
+			}
+			l := &limits{
+				object:               valueOrDefault(p.MaxObjectSizeKiB, defaultMaxObjectSizeKiB) * bytesInKiB,
+				data:                 valueOrDefault(p.MaxDataSizeKiB, defaultMaxDataSizeKiB) * bytesInKiB,
+				annotations:          valueOrDefault(p.MaxAnnotationsSizeKiB, defaultMaxAnnotationsSizeKiB) * bytesInKiB,
+				labelValueLength:     valueOrDefault(p.MaxLabelValueLength, defaultMaxLabelValueLength),
+				warningMarginPercent: p.WarningMarginPercent,
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				return checkSizes(object.K8sObject, l, p.ClientSideApply)
+			}, nil
+		}),
+	})
+}
+
+func checkSizes(obj k8sutil.Object, l *limits, clientSideApply bool) []diagnostic.Diagnostic {
+	var results []diagnostic.Diagnostic
+	serialized, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	objectSize := len(serialized)
+	annotationsTotal := annotationsSize(obj.GetAnnotations())
+	if _, found := obj.GetAnnotations()[lastAppliedConfigAnnotation]; clientSideApply && !found {
+		// kubectl stores the object, without the annotation itself, as JSON in the annotation.
+		lastAppliedSize := len(lastAppliedConfigAnnotation) + objectSize
+		objectSize += lastAppliedSize
+		annotationsTotal += lastAppliedSize
+	}
+
+	if l.exceeds(objectSize, l.object) {
+		what := "object is"
+		if clientSideApply {
+			what = "object, including its last-applied configuration, is"
+		}
+		results = append(results, diagnostic.Diagnostic{Message: describe(what, objectSize, l.object)})
+	}
+	if size, kind, found := dataSize(obj); found && l.exceeds(size, l.data) {
+		results = append(results, diagnostic.Diagnostic{Message: describe(fmt.Sprintf("%s data is", kind), size, l.data)})
+	}
+	if l.exceeds(annotationsTotal, l.annotations) {
+		results = append(results, diagnostic.Diagnostic{Message: describe("annotations are", annotationsTotal, l.annotations)})
+	}
+	results = append(results, checkLabelValues("label", obj.GetLabels(), l.labelValueLength)...)
+	if podTemplate, found := extract.PodTemplateSpec(obj); found {
+		if size := annotationsSize(podTemplate.Annotations); l.exceeds(size, l.annotations) {
+			results = append(results, diagnostic.Diagnostic{Message: describe("pod template annotations are", size, l.annotations)})
+		}
+		results = append(results, checkLabelValues("pod template label", podTemplate.Labels, l.labelValueLength)...)
+	}
+	return results
+}
+
+// annotationsSize returns the size of the annotations as computed by the API server.
+func annotationsSize(annotations map[string]string) int {
+	var size int
+	for key, value := range annotations {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// dataSize returns the size of the data of a ConfigMap or Secret as computed by the API server.
+func dataSize(obj k8sutil.Object) (int, string, bool) {
+	var size int
+	switch obj := obj.(type) {
+	case *coreV1.ConfigMap:
+		for _, value := range obj.Data {
+			size += len(value)
+		}
+		for _, value := range obj.BinaryData {
+			size += len(value)
+		}
+		return size, "ConfigMap", true
+	case *coreV1.Secret:
+		for key, value := range obj.Data {
+			// The API server merges stringData into data, overwriting the keys set in both.
+			if _, found := obj.StringData[key]; !found {
+				size += len(value)
+			}
+		}
+		for _, value := range obj.StringData {
+			size += len(value)
+		}
+		return size, "Secret", true
+	}
+	return 0, "", false
+}
+
+func checkLabelValues(what string, labels map[string]string, maxLength int) []diagnostic.Diagnostic {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var results []diagnostic.Diagnostic
+	for _, key := range keys {
+		if value := labels[key]; len(value) > maxLength {
+			results = append(results, diagnostic.Diagnostic{
+				Message: fmt.Sprintf("%s %q has a value of %d characters, above the limit of %d", what, key, len(value), maxLength),
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,121 @@
+package sizelimits
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/sizelimits/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSizeLimits(t *testing.T) {
+	suite.Run(t, new(SizeLimitsTestSuite))
+}
+
+type SizeLimitsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *SizeLimitsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *SizeLimitsTestSuite) addObjects() {
+	s.ctx.AddObject("generated", &coreV1.ConfigMap{
+		TypeMeta:   metaV1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "generated"},
+		Data:       map[string]string{"dashboard.json": strings.Repeat("x", 900*bytesInKiB)},
+	})
+	s.ctx.AddObject("certs", &coreV1.Secret{
+		TypeMeta:   metaV1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "certs"},
+		Data:       map[string][]byte{"ca.crt": make([]byte, 600*bytesInKiB)},
+		StringData: map[string]string{"tls.crt": strings.Repeat("x", 500*bytesInKiB)},
+	})
+	s.ctx.AddObject("app", &appsV1.Deployment{
+		TypeMeta: metaV1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "app",
+			Labels:      map[string]string{"commit": strings.Repeat("a", 64), "app": "app"},
+			Annotations: map[string]string{"dashboard": strings.Repeat("x", 300*bytesInKiB)},
+		},
+		Spec: appsV1.DeploymentSpec{
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"commit": strings.Repeat("a", 64)}},
+			},
+		},
+	})
+}
+
+func (s *SizeLimitsTestSuite) TestDefaultLimits() {
+	s.addObjects()
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"certs": {
+					{Message: "Secret data is 1.1MiB, above the limit of 1.0MiB"},
+				},
+				"app": {
+					{Message: "annotations are 300.0KiB, above the limit of 256.0KiB"},
+					{Message: `label "commit" has a value of 64 characters, above the limit of 63`},
+					{Message: `pod template label "commit" has a value of 64 characters, above the limit of 63`},
+				},
+			},
+		},
+	})
+}
+
+func (s *SizeLimitsTestSuite) TestWarningMarginAndClientSideApply() {
+	s.addObjects()
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{WarningMarginPercent: 20, MaxLabelValueLength: pointers.Int(64)},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"generated": {
+					{Message: "ConfigMap data is 900.0KiB, close to the limit of 1.0MiB"},
+				},
+				"certs": {
+					{Message: "object is 1.3MiB, close to the limit of 1.5MiB"},
+					{Message: "Secret data is 1.1MiB, above the limit of 1.0MiB"},
+				},
+				"app": {
+					{Message: "annotations are 300.0KiB, above the limit of 256.0KiB"},
+				},
+			},
+		},
+		{
+			Param: params.Params{ClientSideApply: true},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"generated": {
+					{Message: "object, including its last-applied configuration, is 1.8MiB, above the limit of 1.5MiB"},
+					{Message: "annotations are 900.2KiB, above the limit of 256.0KiB"},
+				},
+				"certs": {
+					{Message: "object, including its last-applied configuration, is 2.5MiB, above the limit of 1.5MiB"},
+					{Message: "Secret data is 1.1MiB, above the limit of 1.0MiB"},
+					{Message: "annotations are 1.3MiB, above the limit of 256.0KiB"},
+				},
+				"app": {
+					{Message: "annotations are 600.5KiB, above the limit of 256.0KiB"},
+					{Message: `label "commit" has a value of 64 characters, above the limit of 63`},
+					{Message: `pod template label "commit" has a value of 64 characters, above the limit of 63`},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{WarningMarginPercent: 100},
+			ExpectInstantiationError: true,
+		},
+	})
+}
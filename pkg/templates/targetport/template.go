@@ -12,6 +12,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/targetport/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sValidation "k8s.io/apimachinery/pkg/util/validation"
@@ -35,7 +36,7 @@ func init() {
 			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 				podSpec, foundPodSpec := extract.PodSpec(object.K8sObject)
 				if foundPodSpec {
-					return findPodPorts(&podSpec)
+					return findPodPorts(&podSpec, util.ContainerPaths(object.K8sObject))
 				}
 
 				service, foundService := object.K8sObject.(*coreV1.Service)
@@ -50,12 +51,12 @@ func init() {
 	})
 }
 
-func findPodPorts(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
+func findPodPorts(podSpec *customtypes.PodSpec, containerPaths map[string]string) []diagnostic.Diagnostic {
 	var results []diagnostic.Diagnostic
 
 	containers := podSpec.AllContainers()
 	for _, container := range containers {
-		for _, port := range container.Ports {
+		for i, port := range container.Ports {
 			if port.Name == "" {
 				continue
 			}
@@ -65,6 +66,8 @@ func findPodPorts(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 				results = append(results, diagnostic.Diagnostic{
 					Message: fmt.Sprintf("port name %q in container %q %s",
 						port.Name, container.Name, violation),
+					Container: container.Name,
+					FieldPath: fmt.Sprintf("%s.ports[%d].name", containerPaths[container.Name], i),
 				})
 			}
 		}
@@ -24,11 +24,18 @@ func init() {
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			filter, err := p.ContainerFilter()
+			if err != nil {
+				return nil, err
+			}
+			return util.FilteredPerContainerCheck(filter, func(container *v1.Container) []diagnostic.Diagnostic {
 				if container.SecurityContext != nil && container.SecurityContext.ProcMount != nil {
 					if strings.EqualFold(string(*container.SecurityContext.ProcMount), "Unmasked") {
-						return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q exposes /proc unsafely (via procMount=Unmasked).", container.Name)}}
+						return []diagnostic.Diagnostic{{
+							Message:   fmt.Sprintf("container %q exposes /proc unsafely (via procMount=Unmasked).", container.Name),
+							FieldPath: "securityContext.procMount",
+						}}
 					}
 				}
 				return nil
@@ -0,0 +1,115 @@
+package util
+
+import (
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Container types, as accepted by the containerTypes parameter.
+const (
+	InitContainerType          = "init"
+	NativeSidecarContainerType = "native-sidecar"
+	RegularContainerType       = "regular"
+	EphemeralContainerType     = "ephemeral"
+)
+
+// ContainerFilterParams are parameters shared by templates that check containers one by one.
+// Embed them in a template's Params to let users restrict the check to some containers.
+type ContainerFilterParams struct {
+
+	// list of matchers for the names of containers to check. If empty, containers are checked regardless of their name.
+	// +matcher
+	IncludeContainers []string
+
+	// list of matchers for the names of containers not to check.
+	// +matcher
+	ExcludeContainers []string
+
+	// list of matchers for the images of containers to check. If empty, containers are checked regardless of their image.
+	// +matcher
+	IncludeImages []string
+
+	// list of matchers for the images of containers not to check.
+	// +matcher
+	ExcludeImages []string
+
+	// The types of containers to check. If empty, all the containers the template looks at are checked.
+	// +enum=init
+	// +enum=native-sidecar
+	// +enum=regular
+	// +enum=ephemeral
+	ContainerTypes []string
+}
+
+// A ContainerFilter selects the containers a per-container check applies to.
+// A nil ContainerFilter selects all containers.
+type ContainerFilter struct {
+	includeContainers, excludeContainers []func(string) bool
+	includeImages, excludeImages         []func(string) bool
+	containerTypes                       set.StringSet
+}
+
+// ContainerFilter builds the filter described by the params.
+func (p ContainerFilterParams) ContainerFilter() (*ContainerFilter, error) {
+	var (
+		f   ContainerFilter
+		err error
+	)
+	if f.includeContainers, err = matchersFor("includeContainers", p.IncludeContainers); err != nil {
+		return nil, err
+	}
+	if f.excludeContainers, err = matchersFor("excludeContainers", p.ExcludeContainers); err != nil {
+		return nil, err
+	}
+	if f.includeImages, err = matchersFor("includeImages", p.IncludeImages); err != nil {
+		return nil, err
+	}
+	if f.excludeImages, err = matchersFor("excludeImages", p.ExcludeImages); err != nil {
+		return nil, err
+	}
+	f.containerTypes = set.NewStringSet(p.ContainerTypes...)
+	return &f, nil
+}
+
+func matchersFor(paramName string, values []string) ([]func(string) bool, error) {
+	matchers := make([]func(string) bool, 0, len(values))
+	for _, value := range values {
+		m, err := matcher.ForString(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s value %q", paramName, value)
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+func anyMatches(matchers []func(string) bool, value string) bool {
+	for _, m := range matchers {
+		if m(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns whether the filter selects the given container, of the given type.
+func (f *ContainerFilter) Matches(container *v1.Container, containerType string) bool {
+	if f == nil {
+		return true
+	}
+	if f.containerTypes.Cardinality() > 0 && !f.containerTypes.Contains(containerType) {
+		return false
+	}
+	if len(f.includeContainers) > 0 && !anyMatches(f.includeContainers, container.Name) {
+		return false
+	}
+	if anyMatches(f.excludeContainers, container.Name) {
+		return false
+	}
+	if len(f.includeImages) > 0 && !anyMatches(f.includeImages, container.Image) {
+		return false
+	}
+	return !anyMatches(f.excludeImages, container.Image)
+}
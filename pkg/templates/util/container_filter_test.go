@@ -0,0 +1,147 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	batchV1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestFilteredPerContainerCheck(t *testing.T) {
+	object := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{
+			Raw: []byte(`
+apiVersion: v1
+kind: Pod
+spec:
+  initContainers:
+  - name: setup
+  - name: log-shipper
+    restartPolicy: Always
+`),
+		},
+		K8sObject: &v1.Pod{
+			Spec: v1.PodSpec{
+				InitContainers: []v1.Container{
+					{Name: "setup", Image: "busybox"},
+					{Name: "log-shipper", Image: "quay.io/myorg/log-shipper:1.0"},
+				},
+				Containers: []v1.Container{
+					{Name: "app", Image: "quay.io/myorg/app:1.0"},
+					{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.18"},
+				},
+				EphemeralContainers: []v1.EphemeralContainer{
+					{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"}},
+				},
+			},
+		},
+	}
+	// The restartPolicy of native sidecars is only available in the source, which it is read from on load.
+	extract.RecordNativeSidecars(object.K8sObject, object.Metadata.Raw)
+	reportName := func(container *v1.Container) []diagnostic.Diagnostic {
+		return []diagnostic.Diagnostic{{Message: container.Name}}
+	}
+	containerPaths := map[string]string{
+		"setup":       "spec.initContainers[0]",
+		"log-shipper": "spec.initContainers[1]",
+		"app":         "spec.containers[0]",
+		"istio-proxy": "spec.containers[1]",
+		"debugger":    "spec.ephemeralContainers[0]",
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		params   ContainerFilterParams
+		nonInit  bool
+		expected []string
+	}{
+		{
+			name:     "no filter",
+			expected: []string{"setup", "log-shipper", "app", "istio-proxy", "debugger"},
+		},
+		{
+			name:     "no filter, non-init containers",
+			nonInit:  true,
+			expected: []string{"log-shipper", "app", "istio-proxy"},
+		},
+		{
+			name:     "exclude container",
+			params:   ContainerFilterParams{ExcludeContainers: []string{"exact:istio-proxy"}},
+			expected: []string{"setup", "log-shipper", "app", "debugger"},
+		},
+		{
+			name:     "include containers",
+			params:   ContainerFilterParams{IncludeContainers: []string{"^app$", "^setup$"}},
+			expected: []string{"setup", "app"},
+		},
+		{
+			name:     "include images",
+			params:   ContainerFilterParams{IncludeImages: []string{"glob:quay.io/myorg/*"}},
+			expected: []string{"log-shipper", "app"},
+		},
+		{
+			name:     "exclude images",
+			params:   ContainerFilterParams{ExcludeImages: []string{"exact:busybox"}},
+			expected: []string{"log-shipper", "app", "istio-proxy"},
+		},
+		{
+			name:     "container types",
+			params:   ContainerFilterParams{ContainerTypes: []string{InitContainerType, EphemeralContainerType}},
+			expected: []string{"setup", "debugger"},
+		},
+		{
+			name:     "native sidecars",
+			params:   ContainerFilterParams{ContainerTypes: []string{NativeSidecarContainerType}},
+			expected: []string{"log-shipper"},
+		},
+		{
+			name:     "container types, non-init containers",
+			params:   ContainerFilterParams{ContainerTypes: []string{InitContainerType}},
+			nonInit:  true,
+			expected: nil,
+		},
+	} {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			filter, err := c.params.ContainerFilter()
+			require.NoError(t, err)
+			checkFunc := FilteredPerContainerCheck(filter, reportName)
+			if c.nonInit {
+				checkFunc = FilteredPerNonInitContainerCheck(filter, reportName)
+			}
+			var actual []string
+			for _, d := range checkFunc(nil, object) {
+				assert.Equal(t, d.Message, d.Container)
+				assert.Equal(t, containerPaths[d.Container], d.FieldPath)
+				actual = append(actual, d.Message)
+			}
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestPerContainerCheckFieldPaths(t *testing.T) {
+	object := lintcontext.Object{
+		K8sObject: &batchV1.CronJob{
+			Spec: batchV1.CronJobSpec{JobTemplate: batchV1.JobTemplateSpec{Spec: batchV1.JobSpec{Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "app:latest"}}},
+			}}}},
+		},
+	}
+	checkFunc := PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+		return []diagnostic.Diagnostic{{Message: "image", FieldPath: "image"}}
+	})
+	diagnostics := checkFunc(nil, object)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "spec.jobTemplate.spec.template.spec.containers[0].image", diagnostics[0].FieldPath)
+}
+
+func TestContainerFilterInvalidMatcher(t *testing.T) {
+	_, err := ContainerFilterParams{IncludeImages: []string{"semver:not a constraint"}}.ContainerFilter()
+	assert.Error(t, err)
+}
@@ -5,10 +5,11 @@ import (
 )
 
 // DecodeMapStructure decodes the given map[string]interface{} into the given out variable, typically
-// a pointer to a struct.
+// a pointer to a struct. The fields of embedded structs are decoded as if they were fields of the struct.
 func DecodeMapStructure(m map[string]interface{}, out interface{}) error {
 	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		ErrorUnused: true,
+		Squash:      true,
 		TagName:     "json",
 		Result:      out,
 	})
@@ -1,45 +1,114 @@
 package util
 
 import (
+	"fmt"
+
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	v1 "k8s.io/api/core/v1"
 )
 
 // PerContainerCheck returns a check that abstracts away some of the boilerplate of writing a check
 // that applies to containers. The given function is passed each container, and is allowed to return
-// diagnostics if an error is found.
+// diagnostics if an error is found. Diagnostics are attributed to the container they were returned for,
+// and their field paths, which are relative to the container, e.g. image, are made relative to the object.
+// Diagnostics without a field path are attributed to the container itself.
 func PerContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
+	return FilteredPerContainerCheck(nil, matchFunc)
+}
+
+// PerNonInitContainerCheck returns a check that abstracts away some of the boilerplate of writing a check
+// that applies to all non-init containers. The given function is passed each regular container and each native
+// sidecar, and is allowed to return diagnostics if an error is found. Diagnostics are attributed to the container
+// they were returned for.
+func PerNonInitContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
+	return FilteredPerNonInitContainerCheck(nil, matchFunc)
+}
+
+// FilteredPerContainerCheck is like PerContainerCheck, but only passes the containers selected by the filter.
+func FilteredPerContainerCheck(filter *ContainerFilter, matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
+	return perContainerCheck(filter, true, matchFunc)
+}
+
+// FilteredPerNonInitContainerCheck is like PerNonInitContainerCheck, but only passes the containers selected
+// by the filter.
+func FilteredPerNonInitContainerCheck(filter *ContainerFilter, matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
+	return perContainerCheck(filter, false, matchFunc)
+}
+
+func perContainerCheck(filter *ContainerFilter, includeInitAndEphemeral bool, matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
 	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 		podSpec, found := extract.PodSpec(object.K8sObject)
 		if !found {
 			return nil
 		}
+		paths := ContainerPaths(object.K8sObject)
 		var results []diagnostic.Diagnostic
-		containers := podSpec.AllContainers()
-		for i := range containers {
-			results = append(results, matchFunc(&containers[i])...)
+		checkContainers := func(containers []v1.Container, containerType string) {
+			for i := range containers {
+				if !filter.Matches(&containers[i], containerType) {
+					continue
+				}
+				results = append(results, forContainer(containers[i].Name, paths[containers[i].Name], matchFunc(&containers[i]))...)
+			}
+		}
+		if includeInitAndEphemeral {
+			checkContainers(podSpec.InitContainers(), InitContainerType)
+		}
+		checkContainers(podSpec.NativeSidecarContainers(), NativeSidecarContainerType)
+		checkContainers(podSpec.RegularContainers(), RegularContainerType)
+		if includeInitAndEphemeral {
+			checkContainers(ephemeralContainers(podSpec), EphemeralContainerType)
 		}
 		return results
 	}
 }
 
-// PerNonInitContainerCheck returns a check that abstracts away some of the boilerplate of writing a check
-// that applies to all non-init containers. The given function is passed each non-init container,
-// and is allowed to return diagnostics if an error is found.
-func PerNonInitContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
-	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-		podSpec, found := extract.PodSpec(object.K8sObject)
-		if !found {
-			return nil
+func ephemeralContainers(podSpec customtypes.PodSpec) []v1.Container {
+	ephemeral := podSpec.EphemeralContainers()
+	containers := make([]v1.Container, 0, len(ephemeral))
+	for _, e := range ephemeral {
+		containers = append(containers, v1.Container(e.EphemeralContainerCommon))
+	}
+	return containers
+}
+
+// ContainerPaths returns the paths of the containers of the pod spec of the given object, e.g.
+// spec.template.spec.containers[0], by container name.
+func ContainerPaths(obj k8sutil.Object) map[string]string {
+	podSpecPath, found := extract.PodSpecPath(obj)
+	if !found {
+		return nil
+	}
+	podSpec, _ := extract.PodSpec(obj)
+	paths := make(map[string]string)
+	for i, container := range podSpec.PodSpec.InitContainers {
+		paths[container.Name] = fmt.Sprintf("%s.initContainers[%d]", podSpecPath, i)
+	}
+	for i, container := range podSpec.PodSpec.Containers {
+		paths[container.Name] = fmt.Sprintf("%s.containers[%d]", podSpecPath, i)
+	}
+	for i, container := range podSpec.PodSpec.EphemeralContainers {
+		paths[container.Name] = fmt.Sprintf("%s.ephemeralContainers[%d]", podSpecPath, i)
+	}
+	return paths
+}
+
+func forContainer(name, path string, diagnostics []diagnostic.Diagnostic) []diagnostic.Diagnostic {
+	for i := range diagnostics {
+		if diagnostics[i].Container == "" {
+			diagnostics[i].Container = name
 		}
-		var results []diagnostic.Diagnostic
-		containers := podSpec.NonInitContainers()
-		for i := range containers {
-			results = append(results, matchFunc(&containers[i])...)
+		switch {
+		case diagnostics[i].FieldPath == "":
+			diagnostics[i].FieldPath = path
+		case path != "":
+			diagnostics[i].FieldPath = path + "." + diagnostics[i].FieldPath
 		}
-		return results
 	}
+	return diagnostics
 }
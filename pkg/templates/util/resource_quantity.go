@@ -0,0 +1,27 @@
+package util
+
+import (
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// BytesInMiB is the number of bytes in a mebibyte, which is what the MB of the memory parameters of templates is.
+	BytesInMiB = 1024 * 1024
+)
+
+// MemoryInRange returns whether the given memory quantity is in the range between the lowerBoundMB and the
+// upperBoundMB (inclusive), in mebibytes. A nil upper bound is interpreted as infinity.
+func MemoryInRange(quantity *resource.Quantity, lowerBoundMB int, upperBoundMB *int) bool {
+	var upperBoundBytes *int
+	if upperBoundMB != nil {
+		upperBoundBytes = pointers.Int(*upperBoundMB * BytesInMiB)
+	}
+	return ValueInRange(int(quantity.Value()), lowerBoundMB*BytesInMiB, upperBoundBytes)
+}
+
+// CPUInRange returns whether the given CPU quantity is in the range between the lowerBoundMillis and the
+// upperBoundMillis (inclusive), in millicores. A nil upper bound is interpreted as infinity.
+func CPUInRange(quantity *resource.Quantity, lowerBoundMillis int, upperBoundMillis *int) bool {
+	return ValueInRange(int(quantity.MilliValue()), lowerBoundMillis, upperBoundMillis)
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.stackrox.io/kube-linter/internal/pointers"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestMemoryInRange(t *testing.T) {
+	for _, testCase := range []struct {
+		quantity      string
+		lowerBoundMB  int
+		upperBoundMB  *int
+		expectedMatch bool
+	}{
+		{quantity: "512Mi", lowerBoundMB: 512, expectedMatch: true},
+		{quantity: "512M", lowerBoundMB: 512},
+		{quantity: "1Gi", upperBoundMB: pointers.Int(1024), expectedMatch: true},
+		{quantity: "1025Mi", upperBoundMB: pointers.Int(1024)},
+		{quantity: "512", lowerBoundMB: 1},
+	} {
+		c := testCase
+		t.Run(c.quantity, func(t *testing.T) {
+			quantity := resource.MustParse(c.quantity)
+			assert.Equal(t, c.expectedMatch, MemoryInRange(&quantity, c.lowerBoundMB, c.upperBoundMB))
+		})
+	}
+}
+
+func TestCPUInRange(t *testing.T) {
+	for _, testCase := range []struct {
+		quantity         string
+		lowerBoundMillis int
+		upperBoundMillis *int
+		expectedMatch    bool
+	}{
+		{quantity: "100m", lowerBoundMillis: 100, expectedMatch: true},
+		{quantity: "0.1", lowerBoundMillis: 101},
+		{quantity: "2", upperBoundMillis: pointers.Int(2000), expectedMatch: true},
+		{quantity: "1000", lowerBoundMillis: math.MinInt, upperBoundMillis: pointers.Int(64000)},
+	} {
+		c := testCase
+		t.Run(c.quantity, func(t *testing.T) {
+			quantity := resource.MustParse(c.quantity)
+			assert.Equal(t, c.expectedMatch, CPUInRange(&quantity, c.lowerBoundMillis, c.upperBoundMillis))
+		})
+	}
+}
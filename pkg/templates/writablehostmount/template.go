@@ -10,6 +10,7 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	"golang.stackrox.io/kube-linter/pkg/templates/writablehostmount/internal/params"
 )
 
@@ -38,14 +39,19 @@ func init() {
 				if len(hostPaths) == 0 {
 					return nil
 				}
+				containerPaths := util.ContainerPaths(object.K8sObject)
 				var results []diagnostic.Diagnostic
 				for _, container := range podSpec.AllContainers() {
-					for _, mount := range container.VolumeMounts {
+					for i, mount := range container.VolumeMounts {
 						if mount.ReadOnly {
 							continue
 						}
 						if hostPath, exists := hostPaths[mount.Name]; exists {
-							results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("container %s mounts path %s on the host as writable", container.Name, hostPath)})
+							results = append(results, diagnostic.Diagnostic{
+								Message:   fmt.Sprintf("container %s mounts path %s on the host as writable", container.Name, hostPath),
+								Container: container.Name,
+								FieldPath: fmt.Sprintf("%s.volumeMounts[%d]", containerPaths[container.Name], i),
+							})
 						}
 					}
 				}
@@ -0,0 +1,14 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The pitfalls to look for. If empty, all of them are looked for.
+	// +enum=implicit-boolean
+	// +enum=version-number
+	// +enum=octal-mode
+	// +enum=duplicate-key
+	// +enum=tab
+	// +enum=anchor
+	Pitfalls []string
+}
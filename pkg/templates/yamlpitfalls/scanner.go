@@ -0,0 +1,290 @@
+package yamlpitfalls
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	// yaml11Booleans are the plain scalars that YAML 1.1, which Kubernetes uses, reads as booleans while YAML 1.2
+	// reads them as strings.
+	yaml11Booleans = map[string]bool{
+		"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "on": true, "On": true, "ON": true,
+		"n": false, "N": false, "no": false, "No": false, "NO": false, "off": false, "Off": false, "OFF": false,
+	}
+
+	// trailingZeroNumber matches numbers that lose a trailing zero when they are converted to a string, e.g. 1.10.
+	trailingZeroNumber = regexp.MustCompile(`^[-+]?[0-9]+\.[0-9]*0$`)
+
+	octalDigits = regexp.MustCompile(`^[0-7]+$`)
+
+	modeKeys = set.NewFrozenStringSet("defaultMode", "mode")
+)
+
+const (
+	// maxMode is the largest valid file mode, 0777.
+	maxMode = 0777
+)
+
+// A scanner looks for pitfalls in the source of an object.
+type scanner struct {
+	raw []byte
+	// content is the unstructured content of the decoded object, or nil if it could not be converted.
+	content map[string]interface{}
+	enabled set.StringSet
+
+	diagnostics []diagnostic.Diagnostic
+	// nodeLines are the lines at which nodes start, and quotedTabs the non-plain scalars that contain tabs.
+	nodeLines  []int
+	quotedTabs []*yaml.Node
+}
+
+// scan returns the diagnostics for the pitfalls found in the given document, ordered by line.
+func (s *scanner) scan(doc *yaml.Node) []diagnostic.Diagnostic {
+	s.walk(doc, nil, "")
+	if s.enabled.Contains(tab) {
+		s.scanTabs()
+	}
+	sort.SliceStable(s.diagnostics, func(i, j int) bool {
+		return s.diagnostics[i].Line < s.diagnostics[j].Line
+	})
+	return s.diagnostics
+}
+
+func (s *scanner) report(line int, message string, fix *diagnostic.Fix) {
+	s.diagnostics = append(s.diagnostics, diagnostic.Diagnostic{Message: message, Line: line, Fix: fix})
+}
+
+// walk visits the given node, at the given path of keys and indexes in the object, as the value of the given key.
+// Aliases are not followed, so that the content of anchors is only visited once.
+func (s *scanner) walk(n *yaml.Node, path []interface{}, key string) {
+	s.nodeLines = append(s.nodeLines, n.Line)
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, child := range n.Content {
+			s.walk(child, path, key)
+		}
+	case yaml.SequenceNode:
+		for i, child := range n.Content {
+			s.walk(child, append(path[:len(path):len(path)], i), "")
+		}
+	case yaml.MappingNode:
+		seen := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			s.nodeLines = append(s.nodeLines, k.Line)
+			if k.Kind != yaml.ScalarNode {
+				continue
+			}
+			if k.ShortTag() == "!!merge" {
+				s.checkMergeKey(k, v)
+				continue
+			}
+			s.checkScalar(k, nil, "", true)
+			if previous, found := seen[k.Value]; found && s.enabled.Contains(duplicateKey) {
+				s.report(k.Line, fmt.Sprintf("key %q is already set on line %d, whose value is silently overridden by this one",
+					k.Value, previous.Line), nil)
+			}
+			seen[k.Value] = k
+			s.walk(v, append(path[:len(path):len(path)], k.Value), k.Value)
+		}
+	case yaml.ScalarNode:
+		s.checkScalar(n, path, key, false)
+	case yaml.AliasNode:
+		if s.enabled.Contains(anchor) && n.Alias != nil {
+			s.report(n.Line, fmt.Sprintf("alias *%s repeats the %s of anchor &%s on line %d, which is easy to overlook when editing either of them",
+				n.Value, describeKind(n.Alias), n.Alias.Anchor, n.Alias.Line), nil)
+		}
+	}
+}
+
+func describeKind(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "list"
+	}
+	return "value"
+}
+
+func (s *scanner) checkMergeKey(k, v *yaml.Node) {
+	if !s.enabled.Contains(anchor) {
+		return
+	}
+	var sources []string
+	aliases := []*yaml.Node{v}
+	if v.Kind == yaml.SequenceNode {
+		aliases = v.Content
+	}
+	for _, alias := range aliases {
+		if alias.Kind == yaml.AliasNode && alias.Alias != nil {
+			sources = append(sources, fmt.Sprintf("&%s on line %d", alias.Alias.Anchor, alias.Alias.Line))
+		}
+	}
+	merged := "a mapping"
+	if len(sources) > 0 {
+		merged = "the mapping of anchor " + strings.Join(sources, ", ")
+	}
+	s.report(k.Line, fmt.Sprintf("merge key << merges %s into this mapping, whose own keys silently take precedence; "+
+		"merge keys are not part of YAML 1.2", merged), nil)
+}
+
+// checkScalar checks a plain scalar, which is either a key or the value of the given key at the given path.
+func (s *scanner) checkScalar(n *yaml.Node, path []interface{}, key string, isKey bool) {
+	if n.Style != 0 {
+		if strings.Contains(n.Value, "\t") {
+			s.quotedTabs = append(s.quotedTabs, n)
+		}
+		return
+	}
+	if truth, found := yaml11Booleans[n.Value]; found && s.enabled.Contains(implicitBoolean) {
+		message := fmt.Sprintf("unquoted %s is the boolean %t in YAML 1.1, which Kubernetes uses, but the string %q in YAML 1.2",
+			n.Value, truth, n.Value)
+		var fix *diagnostic.Fix
+		if isKey {
+			message += ", and Kubernetes turns it into the key " + strconv.Quote(strconv.FormatBool(truth))
+			fix = quoteFix(n)
+		} else if value, found := lookup(s.content, path); found {
+			if _, isBool := value.(bool); isBool {
+				fix = replaceFix(n, strconv.FormatBool(truth), fmt.Sprintf("Write %t, which is a boolean in all YAML versions.", truth))
+			} else {
+				fix = quoteFix(n)
+			}
+		}
+		s.report(n.Line, message, fix)
+	}
+	if trailingZeroNumber.MatchString(n.Value) && s.enabled.Contains(versionNumber) && (isKey || s.isStringField(path, n.Value)) {
+		number, err := strconv.ParseFloat(n.Value, 64)
+		if err == nil {
+			s.report(n.Line, fmt.Sprintf("unquoted %s is the number %s, not the string %q",
+				n.Value, strconv.FormatFloat(number, 'f', -1, 64), n.Value), quoteFix(n))
+		}
+	}
+	if modeKeys.Contains(key) && octalDigits.MatchString(n.Value) && s.enabled.Contains(octalMode) {
+		s.checkMode(n)
+	}
+}
+
+// isStringField returns whether the value at the given path of the decoded object is a string that is not the
+// canonical form of the given quantity.
+func (s *scanner) isStringField(path []interface{}, text string) bool {
+	value, found := lookup(s.content, path)
+	str, isString := value.(string)
+	if !found || !isString {
+		return false
+	}
+	// Resource quantities are strings in the decoded object, but numbers with trailing zeros mean the same quantity.
+	if decoded, err := resource.ParseQuantity(str); err == nil {
+		if written, err := resource.ParseQuantity(text); err == nil && written.Cmp(decoded) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// checkMode reports modes that are written as decimal numbers, but are only valid modes as octal ones, e.g. 644.
+// Octal modes with a leading zero, e.g. 0644, are read as intended by Kubernetes, which uses YAML 1.1.
+func (s *scanner) checkMode(n *yaml.Node) {
+	if strings.HasPrefix(n.Value, "0") {
+		return
+	}
+	decimal, err := strconv.ParseInt(n.Value, 10, 64)
+	if err != nil || decimal <= maxMode {
+		return
+	}
+	if mode, err := strconv.ParseInt(n.Value, 8, 64); err != nil || mode > maxMode {
+		return
+	}
+	s.report(n.Line, fmt.Sprintf("mode %s is a decimal number, which is not a valid mode, rather than the octal mode 0%s", n.Value, n.Value),
+		replaceFix(n, "0"+n.Value, fmt.Sprintf("Write the octal mode 0%s with a leading zero.", n.Value)))
+}
+
+// scanTabs reports the lines that contain tabs, outside of comments and of scalars whose content has tabs.
+func (s *scanner) scanTabs() {
+	skipped := make(map[int]bool)
+	sort.Ints(s.nodeLines)
+	for _, n := range s.quotedTabs {
+		// The scalar spans the lines up to the start of the next node.
+		next := sort.SearchInts(s.nodeLines, n.Line+1)
+		end := bytes.Count(s.raw, []byte("\n")) + 1
+		if next < len(s.nodeLines) {
+			end = s.nodeLines[next] - 1
+		}
+		for line := n.Line; line <= end; line++ {
+			skipped[line] = true
+		}
+	}
+	for i, line := range strings.Split(string(s.raw), "\n") {
+		lineNumber := i + 1
+		if skipped[lineNumber] {
+			continue
+		}
+		var replacements []diagnostic.Replacement
+		for col, char := range line {
+			if char == '#' && (col == 0 || line[col-1] == ' ' || line[col-1] == '\t') {
+				break
+			}
+			if char == '\t' {
+				replacements = append(replacements, diagnostic.Replacement{
+					StartLine: lineNumber, StartColumn: col + 1, EndLine: lineNumber, EndColumn: col + 2, InsertedText: " ",
+				})
+			}
+		}
+		if len(replacements) > 0 {
+			s.report(lineNumber, "tab character, which YAML doesn't allow in indentation and which editors display inconsistently",
+				&diagnostic.Fix{Description: "Replace the tabs with spaces.", Replacements: replacements})
+		}
+	}
+}
+
+func quoteFix(n *yaml.Node) *diagnostic.Fix {
+	return replaceFix(n, strconv.Quote(n.Value), fmt.Sprintf("Quote %s to make it a string in all YAML versions.", n.Value))
+}
+
+// replaceFix returns a fix replacing the source of the given single-line plain scalar with the given text.
+func replaceFix(n *yaml.Node, text, description string) *diagnostic.Fix {
+	return &diagnostic.Fix{
+		Description: description,
+		Replacements: []diagnostic.Replacement{{
+			StartLine:    n.Line,
+			StartColumn:  n.Column,
+			EndLine:      n.Line,
+			EndColumn:    n.Column + len(n.Value),
+			InsertedText: text,
+		}},
+	}
+}
+
+// lookup returns the value at the given path of keys and indexes in the unstructured content.
+func lookup(content map[string]interface{}, path []interface{}) (interface{}, bool) {
+	var current interface{} = content
+	for _, elem := range path {
+		switch elem := elem.(type) {
+		case string:
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if current, ok = m[elem]; !ok {
+				return nil, false
+			}
+		case int:
+			l, ok := current.([]interface{})
+			if !ok || elem >= len(l) {
+				return nil, false
+			}
+			current = l[elem]
+		}
+	}
+	return current, current != nil
+}
@@ -0,0 +1,60 @@
+package yamlpitfalls
+
+import (
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/yamlpitfalls/internal/params"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	templateKey = "yaml-pitfalls"
+)
+
+// The pitfalls the template looks for.
+const (
+	implicitBoolean = "implicit-boolean"
+	versionNumber   = "version-number"
+	octalMode       = "octal-mode"
+	duplicateKey    = "duplicate-key"
+	tab             = "tab"
+	anchor          = "anchor"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "YAML Pitfalls",
+		Key:         templateKey,
+		Description: "Flag YAML constructs in the source of objects whose meaning depends on the YAML version or is easily misread, such as unquoted yes/no, version-like numbers, octal modes written as decimals, duplicate keys, tabs and anchors",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			enabled := set.NewStringSet(p.Pitfalls...)
+			if enabled.IsEmpty() {
+				enabled.AddAll(implicitBoolean, versionNumber, octalMode, duplicateKey, tab, anchor)
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				if len(object.Metadata.Raw) == 0 {
+					return nil
+				}
+				var doc yaml.Node
+				if err := yaml.Unmarshal(object.Metadata.Raw, &doc); err != nil {
+					return nil
+				}
+				// The decoded object tells whether a field is a string or a boolean, e.g. to pick the fix.
+				content, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(object.K8sObject)
+				s := &scanner{raw: object.Metadata.Raw, content: content, enabled: enabled}
+				return s.scan(&doc)
+			}, nil
+		}),
+	})
+}
@@ -0,0 +1,141 @@
+package yamlpitfalls
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/yamlpitfalls/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	deploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels: &labels
+    app: app
+    app: web
+spec:
+  selector:
+    matchLabels: *labels
+  template:
+    metadata:
+      labels:
+        <<: *labels
+        tier: frontend
+    spec:
+      hostNetwork: yes
+      containers:
+      - name:	app
+        image: app:1.10
+      volumes:
+      - name: config
+        secret:
+          secretName: config
+          defaultMode: 0644
+          items:
+          - key: script
+            path: run.sh
+            mode: 755
+`
+
+	configMapYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  on: enabled
+  1.10: supported
+  release: "1.10"
+  comment: "a	b"
+`
+)
+
+func TestYAMLPitfalls(t *testing.T) {
+	suite.Run(t, new(YAMLPitfallsTestSuite))
+}
+
+type YAMLPitfallsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *YAMLPitfallsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *YAMLPitfallsTestSuite) addObjectFromYAML(raw string, obj k8sutil.Object) {
+	s.Require().NoError(yaml.Unmarshal([]byte(raw), obj))
+	s.ctx.AddObject(obj.GetName(), obj)
+	s.ctx.SetRaw(obj.GetName(), []byte(raw))
+}
+
+func (s *YAMLPitfallsTestSuite) TestPitfalls() {
+	s.addObjectFromYAML(deploymentYAML, &appsV1.Deployment{})
+	s.addObjectFromYAML(configMapYAML, &coreV1.ConfigMap{})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: `key "app" is already set on line 6, whose value is silently overridden by this one`},
+					{Message: "alias *labels repeats the mapping of anchor &labels on line 5, which is easy to overlook when editing either of them"},
+					{Message: "merge key << merges the mapping of anchor &labels on line 5 into this mapping, whose own keys silently take precedence; merge keys are not part of YAML 1.2"},
+					{Message: `unquoted yes is the boolean true in YAML 1.1, which Kubernetes uses, but the string "yes" in YAML 1.2`},
+					{Message: "tab character, which YAML doesn't allow in indentation and which editors display inconsistently"},
+					{Message: "mode 755 is a decimal number, which is not a valid mode, rather than the octal mode 0755"},
+				},
+				"config": {
+					{Message: `unquoted on is the boolean true in YAML 1.1, which Kubernetes uses, but the string "on" in YAML 1.2, and Kubernetes turns it into the key "true"`},
+					{Message: `unquoted 1.10 is the number 1.1, not the string "1.10"`},
+				},
+			},
+		},
+		{
+			Param: params.Params{Pitfalls: []string{"implicit-boolean", "octal-mode"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {
+					{Message: `unquoted yes is the boolean true in YAML 1.1, which Kubernetes uses, but the string "yes" in YAML 1.2`},
+					{Message: "mode 755 is a decimal number, which is not a valid mode, rather than the octal mode 0755"},
+				},
+				"config": {
+					{Message: `unquoted on is the boolean true in YAML 1.1, which Kubernetes uses, but the string "on" in YAML 1.2, and Kubernetes turns it into the key "true"`},
+				},
+			},
+		},
+	})
+}
+
+func (s *YAMLPitfallsTestSuite) TestFixes() {
+	s.addObjectFromYAML(deploymentYAML, &appsV1.Deployment{})
+	s.addObjectFromYAML(configMapYAML, &coreV1.ConfigMap{})
+	checkFunc, err := s.Template.Instantiate(params.Params{Pitfalls: []string{"implicit-boolean", "version-number", "octal-mode", "tab"}})
+	s.Require().NoError(err)
+
+	fixes := make(map[int]diagnostic.Replacement)
+	for _, obj := range s.ctx.Objects() {
+		if obj.GetK8sObjectName().Name != "app" {
+			continue
+		}
+		for _, diag := range checkFunc(s.ctx, obj) {
+			s.Require().NotNil(diag.Fix, diag.Message)
+			s.Require().Len(diag.Fix.Replacements, 1, diag.Message)
+			fixes[diag.Line] = diag.Fix.Replacements[0]
+		}
+	}
+	s.Equal(map[int]diagnostic.Replacement{
+		17: {StartLine: 17, StartColumn: 20, EndLine: 17, EndColumn: 23, InsertedText: "true"},
+		19: {StartLine: 19, StartColumn: 14, EndLine: 19, EndColumn: 15, InsertedText: " "},
+		29: {StartLine: 29, StartColumn: 19, EndLine: 29, EndColumn: 22, InsertedText: "0755"},
+	}, fixes)
+}
@@ -0,0 +1,94 @@
+locals {
+  labels = {
+    app = "web"
+  }
+}
+
+variable "environment" {
+  type    = string
+  default = "staging"
+}
+
+resource "kubernetes_manifest" "config" {
+  manifest = yamldecode(file("${path.module}/manifests/configmap.yaml"))
+}
+
+resource "kubernetes_manifest" "service_account" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ServiceAccount"
+    metadata = {
+      name      = "web"
+      namespace = "web-${var.environment}"
+      labels    = local.labels
+    }
+  }
+}
+
+resource "kubernetes_deployment_v1" "web" {
+  metadata {
+    name   = "web"
+    labels = local.labels
+  }
+
+  spec {
+    replicas = 2
+
+    selector {
+      match_labels = local.labels
+    }
+
+    template {
+      metadata {
+        labels = local.labels
+      }
+
+      spec {
+        service_account_name = "web"
+        host_pid             = true
+
+        container {
+          name  = "web"
+          image = "nginx:1.23"
+
+          port {
+            container_port = 80
+          }
+
+          security_context {
+            read_only_root_filesystem = true
+          }
+        }
+      }
+    }
+  }
+
+  wait_for_rollout = false
+}
+
+resource "kubernetes_manifest" "from_variable" {
+  manifest = var.manifest
+}
+
+resource "helm_release" "app" {
+  name      = "app"
+  namespace = "apps"
+  chart     = "../mychart"
+
+  values = [
+    <<-EOT
+    replicaCount: 3
+    EOT
+  ]
+
+  set {
+    name  = "autoscaling.enabled"
+    value = "false"
+  }
+}
+
+resource "helm_release" "ingress" {
+  name       = "ingress"
+  repository = "https://kubernetes.github.io/ingress-nginx"
+  chart      = "ingress-nginx"
+}